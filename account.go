@@ -1,15 +1,26 @@
 package nano
 
 import (
+	"context"
+
 	"github.com/s1na/nano-go/rpc"
 )
 
 type Account struct {
 	Id string
+
+	ledger rpc.LedgerReader
 }
 
-func NewAccount() *Account {
+// Returns an account reading its state through ledger.
+func NewAccount(ledger rpc.LedgerReader) *Account {
 	a := new(Account)
+	a.ledger = ledger
 
 	return a
 }
+
+// Returns the balance and the pending balance of the account.
+func (a *Account) Balance(ctx context.Context) (string, string, error) {
+	return a.ledger.AccountBalance(ctx, a.Id)
+}
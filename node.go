@@ -1,6 +1,8 @@
 package nano
 
 import (
+	"context"
+
 	"github.com/s1na/nano-go/rpc"
 )
 
@@ -10,22 +12,34 @@ var (
 
 type Node struct {
 	Wallets map[string]*Wallet
-	Version string
+
+	wallets rpc.WalletController
+	ledger  rpc.LedgerReader
+	control rpc.NodeController
+	version string
 }
 
-func NewNode() *Node {
+// Returns a node managing wallets through wallets, reading their
+// accounts through ledger and controlling the node through control,
+// e.g. all three an *rpc.Client or a mock from package rpcmock.
+func NewNode(wallets rpc.WalletController, ledger rpc.LedgerReader, control rpc.NodeController) *Node {
 	n := new(Node)
 	n.Wallets = make(map[string]*Wallet)
+	n.wallets = wallets
+	n.ledger = ledger
+	n.control = control
 
 	return n
 }
 
+// Returns the node behind the default client of package rpc.
 func GetNode() *Node {
 	if node != nil {
 		return node
 	}
 
-	node = NewNode()
+	c := rpc.Default()
+	node = NewNode(c, c, c)
 
 	return node
 }
@@ -36,32 +50,39 @@ func (n *Node) GetWallet(id string) *Wallet {
 	return w
 }
 
-func (n *Node) CreateWallet() (*Wallet, error) {
-	w := NewWallet()
-
-	w.Id, err := rpc.CreateWallet()
+func (n *Node) CreateWallet(ctx context.Context) (*Wallet, error) {
+	id, err := n.wallets.CreateWallet(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return w
+	w := NewWallet(n.wallets, n.ledger)
+	w.Id = id
+	n.Wallets[id] = w
+
+	return w, nil
 }
 
-func (n *Node) Version() (string, error) {
-	if n.Version != "" {
-		return n.Version
+func (n *Node) Version(ctx context.Context) (string, error) {
+	if n.version != "" {
+		return n.version, nil
 	}
 
-	v, err := rpc.Version()
+	v, err := n.control.Version(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	n.Version = v["node_version"]
+	n.version = v["node_vendor"]
+	if n.version == "" {
+		n.version = v["node_version"]
+	}
 
-	return n.Version
+	return n.version, nil
 }
 
-func (n *Node) Stop() error {
-	return rpc.Stop()
+func (n *Node) Stop(ctx context.Context) error {
+	_, err := n.control.Stop(ctx)
+
+	return err
 }
@@ -0,0 +1,83 @@
+package nano_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/s1na/nano-go"
+	"github.com/s1na/nano-go/rpc/rpcmock"
+)
+
+func TestNodeWithMock(t *testing.T) {
+	m := &rpcmock.API{
+		CreateWalletFunc: func(ctx context.Context) (string, error) {
+			return "W", nil
+		},
+		CreateAccountFunc: func(ctx context.Context, wallet string, work bool) (string, error) {
+			return "nano_a", nil
+		},
+		AccountListFunc: func(ctx context.Context, wallet string) ([]string, error) {
+			return []string{"nano_a", "nano_b"}, nil
+		},
+		AccountBalanceFunc: func(ctx context.Context, account string) (string, string, error) {
+			return "10", "1", nil
+		},
+		VersionFunc: func(ctx context.Context) (map[string]string, error) {
+			return map[string]string{"node_vendor": "Nano V25.0"}, nil
+		},
+	}
+	n := nano.NewNode(m, m, m)
+	ctx := context.Background()
+
+	w, err := n.CreateWallet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Id != "W" || n.GetWallet("W") != w {
+		t.Errorf("created wallet %q isn't kept by the node", w.Id)
+	}
+
+	a, err := w.CreateAccount(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Known accounts are reused, others are added.
+	accounts, err := w.ListAccounts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 2 || accounts[0] != a || accounts[1].Id != "nano_b" || w.Accounts["nano_b"] != accounts[1] {
+		t.Errorf("listed accounts %v", accounts)
+	}
+
+	balance, pending, err := accounts[1].Balance(ctx)
+	if err != nil || balance != "10" || pending != "1" {
+		t.Errorf("balance = %s, %s, %v", balance, pending, err)
+	}
+
+	// The version is asked once.
+	for i := 0; i < 2; i++ {
+		if v, err := n.Version(ctx); err != nil || v != "Nano V25.0" {
+			t.Errorf("version = %q, %v", v, err)
+		}
+	}
+
+	if err = n.Stop(ctx); !errors.Is(err, rpcmock.ErrNotMocked) {
+		t.Errorf("stop returned %v, want ErrNotMocked", err)
+	}
+
+	want := []rpcmock.Call{
+		{Method: "CreateWallet", Args: []interface{}{}},
+		{Method: "CreateAccount", Args: []interface{}{"W", true}},
+		{Method: "AccountList", Args: []interface{}{"W"}},
+		{Method: "AccountBalance", Args: []interface{}{"nano_b"}},
+		{Method: "Version", Args: []interface{}{}},
+		{Method: "Stop", Args: []interface{}{}},
+	}
+	if got := m.Calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
@@ -1,49 +1,140 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
 )
 
+// Account is the ledger entry of an account, as returned by
+// AccountInfo, AccountsInfo and Ledger. Amounts are in raw.
+// Fields the node did not return are left zero.
 type Account struct {
 	Frontier            string
 	OpenBlock           string
 	RepresentativeBlock string
-	Balance             string
-	Modified            string
-	BlockCount          string
-	Representative      string
-	Weight              string
-	Pending             string
-}
-
-// Creates a new account, insert next deterministic key in wallet.
-// If work is false, it disables work generation after creating account (>= v8.1).
-// Requires enable_control.
-func CreateAccount(wallet string, work bool) (string, error) {
-	payload := map[string]interface{}{
-		"wallet": wallet,
-		"work":   work,
-	}
-
-	return client.fetchString("account_create", payload, "account")
-}
-
-// Returns account number corresponding to the public key.
-func GetAccount(key string) (string, error) {
-	payload := map[string]interface{}{
-		"key": key,
+	Balance             *big.Int
+	Modified            time.Time
+	BlockCount          uint64
+	// Version of the latest epoch upgrade of the account (>= v10.0).
+	AccountVersion uint64
+	// Height and hash of the latest confirmed block (>= v19.0).
+	ConfirmationHeight         uint64
+	ConfirmationHeightFrontier string
+	Representative             string
+	Weight                     *big.Int
+	Pending                    *big.Int
+
+	// Set with include_confirmed, counting only confirmed blocks (>= v22.0).
+	ConfirmedBalance        *big.Int
+	ConfirmedHeight         uint64
+	ConfirmedFrontier       string
+	ConfirmedRepresentative string
+	ConfirmedPending        *big.Int
+}
+
+func (a *Account) UnmarshalJSON(data []byte) error {
+	var r struct {
+		Frontier                   string `json:"frontier"`
+		OpenBlock                  string `json:"open_block"`
+		RepresentativeBlock        string `json:"representative_block"`
+		Balance                    string `json:"balance"`
+		Modified                   string `json:"modified_timestamp"`
+		BlockCount                 string `json:"block_count"`
+		AccountVersion             string `json:"account_version"`
+		ConfirmationHeight         string `json:"confirmation_height"`
+		ConfirmationHeightFrontier string `json:"confirmation_height_frontier"`
+		Representative             string `json:"representative"`
+		Weight                     string `json:"weight"`
+		Pending                    string `json:"pending"`
+		ConfirmedBalance           string `json:"confirmed_balance"`
+		ConfirmedHeight            string `json:"confirmed_height"`
+		ConfirmedFrontier          string `json:"confirmed_frontier"`
+		ConfirmedRepresentative    string `json:"confirmed_representative"`
+		ConfirmedPending           string `json:"confirmed_pending"`
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+
+	*a = Account{
+		Frontier:                   r.Frontier,
+		OpenBlock:                  r.OpenBlock,
+		RepresentativeBlock:        r.RepresentativeBlock,
+		ConfirmationHeightFrontier: r.ConfirmationHeightFrontier,
+		Representative:             r.Representative,
+		ConfirmedFrontier:          r.ConfirmedFrontier,
+		ConfirmedRepresentative:    r.ConfirmedRepresentative,
+	}
+
+	var modified uint64
+	p := accountParser{}
+	p.raw(&a.Balance, "balance", r.Balance)
+	p.raw(&a.Weight, "weight", r.Weight)
+	p.raw(&a.Pending, "pending", r.Pending)
+	p.raw(&a.ConfirmedBalance, "confirmed_balance", r.ConfirmedBalance)
+	p.raw(&a.ConfirmedPending, "confirmed_pending", r.ConfirmedPending)
+	p.uint(&modified, "modified_timestamp", r.Modified)
+	p.uint(&a.BlockCount, "block_count", r.BlockCount)
+	p.uint(&a.AccountVersion, "account_version", r.AccountVersion)
+	p.uint(&a.ConfirmationHeight, "confirmation_height", r.ConfirmationHeight)
+	p.uint(&a.ConfirmedHeight, "confirmed_height", r.ConfirmedHeight)
+	if p.err != nil {
+		return p.err
+	}
+
+	if r.Modified != "" {
+		a.Modified = time.Unix(int64(modified), 0)
+	}
+
+	return nil
+}
+
+// Parses the string fields of an account, keeping the first error.
+// Empty fields were not returned and are skipped.
+type accountParser struct {
+	err error
+}
+
+func (p *accountParser) raw(dst **big.Int, field, s string) {
+	if s == "" || p.err != nil {
+		return
+	}
+
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok || v.Sign() < 0 || v.BitLen() > 128 {
+		p.err = &json.UnmarshalTypeError{Value: "string " + strconv.Quote(s), Type: reflect.TypeOf(v), Field: field}
+		return
+	}
+
+	*dst = v
+}
+
+func (p *accountParser) uint(dst *uint64, field, s string) {
+	if s == "" || p.err != nil {
+		return
+	}
+
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		p.err = &json.UnmarshalTypeError{Value: "string " + strconv.Quote(s), Type: reflect.TypeOf(v), Field: field}
+		return
 	}
 
-	return client.fetchString("account_get", payload, "account")
+	*dst = v
 }
 
 // Returns frontier, open block, change representative block,
 // balance, last modified timestamp from local database
 // and block count for account.
 // Additionally returns representative, voting weight and
-// pending balance for account, if respective parameters are set (>= v8.1).
-func AccountInfo(account string, representative, weight, pending bool) (map[string]string, error) {
+// pending balance for account, if respective parameters are set (>= v8.1),
+// and the confirmed state of the account if includeConfirmed is set (>= v22.0).
+func (c *Client) AccountInfo(ctx context.Context, account string, representative, weight, pending, includeConfirmed bool) (*Account, error) {
 	payload := map[string]interface{}{
 		"account":        account,
 		"representative": representative,
@@ -51,257 +142,81 @@ func AccountInfo(account string, representative, weight, pending bool) (map[stri
 		"pending":        pending,
 	}
 
-	return client.fetchMap("account_info", payload, "")
-}
-
-// Returns how many RAW is owned (balance) and how many
-// have not yet been received by account (pending).
-func AccountBalance(account string) (string, string, error) {
-	payload := map[string]interface{}{
-		"account": account,
-	}
-
-	r, err := client.fetchMap("account_balance", payload, "")
-	if err != nil {
-		return "", "", err
+	if includeConfirmed {
+		payload["include_confirmed"] = true
 	}
 
-	balance, ok := r["balance"]
-	if !ok {
-		return "", "", errors.New("Response of account_balance has no balance")
-	}
-
-	pending, ok := r["pending"]
-	if !ok {
-		return "", "", errors.New("Response of account_balance has no pending")
-	}
-
-	return balance, pending, nil
-}
-
-// Returns number of blocks for a specific account.
-func AccountBlockCount(account string) (int, error) {
-	payload := map[string]interface{}{
-		"account": account,
-	}
-
-	return client.fetchInt("account_block_count", payload, "block_count")
-}
-
-// Reports send/receive information for a account.
-func AccountHistory(account string, count int) ([]map[string]string, error) {
-	payload := map[string]interface{}{
-		"account": account,
-		"count":   count,
-	}
-
-	raw, err := client.call("account_history", payload)
-	if err != nil {
-		return nil, err
-	}
-
-	var r []map[string]string
-	if err = json.Unmarshal(raw, &r); err != nil {
+	info := new(Account)
+	if err := c.fetch(ctx, "account_info", payload, info, "frontier", "balance", "block_count"); err != nil {
 		return nil, err
 	}
 
-	return r, nil
-}
-
-// Returns the public key for account.
-func AccountKey(account string) (string, error) {
-	payload := map[string]interface{}{
-		"account": account,
-	}
-
-	return client.fetchString("account_key", payload, "key")
-}
-
-// Returns the representative for account.
-func AccountRepresentative(account string) (string, error) {
-	payload := map[string]interface{}{
-		"account": account,
-	}
-
-	return client.fetchString("account_representative", payload, "representative")
+	return info, nil
 }
 
-// Sets the representative for account in wallet.
-// If provided, uses work value for block from external source (>= v8.1).
-// Returns the change block.
-// Requires enable_control.
-func SetAccountRepresentative(wallet, account, representative, work string) (string, error) {
-	payload := map[string]interface{}{
-		"wallet":         wallet,
-		"account":        account,
-		"representative": representative,
-	}
-
-	if work != "" {
-		payload["work"] = work
-	}
+// Like AccountInfo, but for every account, with bounded concurrency.
+// Unopened accounts are left out, any other failure is returned.
+func (c *Client) AccountsInfo(ctx context.Context, accounts []string, representative, weight, pending, includeConfirmed bool) (map[string]*Account, error) {
+	results := c.BulkAccountInfo(ctx, accounts, representative, weight, pending, includeConfirmed, BulkOptions{})
 
-	return client.fetchString("account_representative_set", payload, "block")
-}
-
-// Returns the voting weight for account.
-func AccountWeight(account string) (string, error) {
-	payload := map[string]interface{}{
-		"account": account,
+	infos := make(map[string]*Account, len(results))
+	for _, account := range dedupe(accounts) {
+		r := results[account]
+		switch {
+		case errors.Is(r.Err, ErrAccountNotFound):
+		case r.Err != nil:
+			return nil, r.Err
+		default:
+			infos[account] = r.Value
+		}
 	}
 
-	return client.fetchString("account_weight", payload, "weight")
+	return infos, nil
 }
 
-// Returns how many RAW is owned and
-// how many have not yet been received by accounts list.
-func AccountsBalances(accounts []string) (map[string]map[string]string, error) {
-	payload := map[string]interface{}{
-		"accounts": accounts,
-	}
-
-	raw, err := client.call("accounts_balances", payload)
-	if err != nil {
-		return nil, err
-	}
-
-	var r map[string]map[string]map[string]string
-	if err = json.Unmarshal(raw, &r); err != nil {
+// Reports send/receive information for a account.
+func (c *Client) AccountHistory(ctx context.Context, account string, count int) ([]map[string]string, error) {
+	var entries []map[string]string
+	if _, err := c.accountHistory(ctx, account, "", count, &entries); err != nil {
 		return nil, err
 	}
 
-	balances, ok := r["balances"]
-	if !ok {
-		return nil, errors.New("Response of accounts_balances is empty")
-	}
-
-	return balances, nil
-}
-
-// Returns a list of pairs of account and block hash
-// representing the head block for accounts list.
-func AccountsFrontiers(accounts []string) (map[string]string, error) {
-	payload := map[string]interface{}{
-		"accounts": accounts,
-	}
-
-	return client.fetchMap("accounts_frontiers", payload, "frontiers")
-}
-
-// Returns a list of block hashes which have not
-// yet been received by these accounts.
-// If threshold is not empty, returns a list of pending
-// block hashes with amount more or equal to threshold (>= v8.0).
-// If source is not empty, returns a list of pending
-// block hashes with amount and source accounts (>= v8.1).
-func AccountsPending(accounts []string, count int, threshold, source string) (map[string]interface{}, error) {
-	payload := map[string]interface{}{
-		"accounts": accounts,
-		"count":    count,
-	}
-
-	if threshold != "" {
-		payload["threshold"] = threshold
-	}
-
-	if source != "" {
-		payload["source"] = source
-	}
-
-	return client.fetchMapInterface("accounts_pending", payload, "blocks")
-}
-
-// Returns a list of pairs of delegator names given
-// account a representative and its balance (>= v8.0).
-func Delegators(account string) (map[string]string, error) {
-	payload := map[string]interface{}{
-		"account": account,
-	}
-
-	return client.fetchMap("delegators", payload, "delegators")
+	return entries, nil
 }
 
-// Get number of delegators for a specific
-// representative account (>= v8.0).
-func DelegatorsCount(account string) (int, error) {
-	payload := map[string]interface{}{
-		"account": account,
-	}
-
-	return client.fetchInt("delegators_count", payload, "count")
-}
-
-// Returns a list of pairs of account and block hash
-// representing the head block starting at account up to count.
-func Frontiers(account string, count int) (map[string]string, error) {
+// Decodes count history entries of account, starting at head
+// or the frontier if head is empty, into entries. Returns the
+// hash of the block to start the next page at, if any.
+func (c *Client) accountHistory(ctx context.Context, account, head string, count int, entries interface{}) (string, error) {
 	payload := map[string]interface{}{
 		"account": account,
 		"count":   count,
 	}
 
-	return client.fetchMap("frontiers", payload, "frontiers")
-}
-
-// Waits for payment of 'amount' to arrive in 'account'
-// or until 'timeout' milliseconds have elapsed.
-func WaitPayment(account, amount string, timeout int) (string, error) {
-	payload := map[string]interface{}{
-		"account": account,
-		"amount":  amount,
-		"timeout": timeout,
+	if head != "" {
+		payload["head"] = head
 	}
 
-	return client.fetchString("payment_wait", payload, "status")
-}
-
-// Checks whether account is a valid account number.
-func ValidateAccountNumber(account string) (bool, error) {
-	payload := map[string]interface{}{
-		"account": account,
+	raw, err := c.call(ctx, "account_history", payload)
+	if err != nil {
+		return "", err
 	}
 
-	return client.isSuccess("validate_account_number", payload, "valid")
-}
-
-// Returns a list of block hashes which have not
-// yet been received by this account.
-// Optionally returns a list of pending block hashes
-// with amount more or equal to threshold (>= v8.0).
-// Optionally returns a list of pending block hashes
-// with amount and source accounts (>= v8.0).
-func Pending(account string, count, threshold int, source bool) (interface{}, error) {
-	payload := map[string]interface{}{
-		"account": account,
-		"count":   count,
-		"source":  source,
+	var r struct {
+		History  json.RawMessage `json:"history"`
+		Previous string          `json:"previous"`
 	}
-
-	if threshold > 0 {
-		payload["threshold"] = threshold
+	if err = json.Unmarshal(raw, &r); err != nil {
+		return "", malformed("account_history", err)
 	}
 
-	return client.fetchInterface("pending", payload, "blocks")
-}
-
-// Retrieves work for account in wallet (>= v8.0).
-// Requires enable_control.
-func GetWork(wallet, account string) (string, error) {
-	payload := map[string]interface{}{
-		"wallet":  wallet,
-		"account": account,
+	if r.History == nil {
+		return "", errMissingKey("account_history", "history")
 	}
 
-	return client.fetchString("work_get", payload, "work")
-}
-
-// Sets work for account in wallet (>= v8.0).
-// Requires enable_control.
-func SetWork(wallet, account, work string) (bool, error) {
-	payload := map[string]interface{}{
-		"wallet":  wallet,
-		"account": account,
-		"work":    work,
+	if err = decodeList(r.History, entries); err != nil {
+		return "", malformed("account_history", err)
 	}
 
-	return client.isSuccess("work_set", payload, "")
+	return r.Previous, nil
 }
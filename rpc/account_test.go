@@ -0,0 +1,135 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+func TestAccountInfo(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+
+	before := time.Now().Add(-time.Second)
+	// Most of the supply, well beyond an int64.
+	_, account := fundedWallet(t, n, c, "340282366920938463463374607431768211450")
+	if _, err := n.Fund(account, "5"); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := c.AccountInfo(ctx, account, true, true, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, _ := new(big.Int).SetString("340282366920938463463374607431768211450", 10)
+	if info.Balance == nil || info.Balance.Cmp(want) != 0 || info.ConfirmedBalance == nil || info.ConfirmedBalance.Cmp(want) != 0 {
+		t.Errorf("balance %v, confirmed %v, want %v", info.Balance, info.ConfirmedBalance, want)
+	}
+	if info.Pending == nil || info.Pending.Int64() != 5 || info.ConfirmedPending == nil || info.ConfirmedPending.Int64() != 5 {
+		t.Errorf("pending %v, confirmed %v, want 5", info.Pending, info.ConfirmedPending)
+	}
+	if info.Weight == nil || info.Representative == "" || info.ConfirmedRepresentative != info.Representative {
+		t.Errorf("weight %v of representative %q, confirmed %q", info.Weight, info.Representative, info.ConfirmedRepresentative)
+	}
+	if info.BlockCount != 1 || info.ConfirmationHeight != 1 || info.ConfirmedHeight != 1 {
+		t.Errorf("block count %d, confirmation height %d, confirmed height %d", info.BlockCount, info.ConfirmationHeight, info.ConfirmedHeight)
+	}
+	if info.Frontier != info.OpenBlock || info.ConfirmedFrontier != info.Frontier || info.ConfirmationHeightFrontier != info.Frontier {
+		t.Errorf("frontiers %+v", info)
+	}
+	if info.Modified.Before(before) || info.Modified.After(time.Now()) {
+		t.Errorf("modified at %v, want about now", info.Modified)
+	}
+
+	// Fields not asked for are left zero.
+	info, err = c.AccountInfo(ctx, account, false, false, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Weight != nil || info.Pending != nil || info.ConfirmedBalance != nil || info.Representative != "" || info.ConfirmedHeight != 0 {
+		t.Errorf("info without optional fields %+v", info)
+	}
+}
+
+func TestAccountsInfo(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+
+	_, opened := fundedWallet(t, n, c, "10")
+	unopened := createAccounts(t, c, 1)[0]
+
+	infos, err := c.AccountsInfo(ctx, []string{opened, unopened, opened}, false, false, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 1 || infos[opened] == nil || infos[opened].Balance.Int64() != 10 {
+		t.Errorf("infos %v, want only the opened account", infos)
+	}
+
+	if _, err = c.AccountsInfo(ctx, []string{opened, badAccount}, false, false, false, false); !errors.Is(err, rpc.ErrBadAccount) {
+		t.Errorf("bad account returned %v, want ErrBadAccount", err)
+	}
+}
+
+func TestAccountJSON(t *testing.T) {
+	var a rpc.Account
+	err := json.Unmarshal([]byte(`{
+		"frontier": "F",
+		"balance": "340282366920938463463374607431768211455",
+		"modified_timestamp": "1501793775",
+		"block_count": "18446744073709551615",
+		"account_version": "2",
+		"weight": "0",
+		"unknown": "field"
+	}`), &a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Balance.String() != "340282366920938463463374607431768211455" || a.Weight == nil || a.Weight.Sign() != 0 {
+		t.Errorf("balance %v, weight %v", a.Balance, a.Weight)
+	}
+	if !a.Modified.Equal(time.Unix(1501793775, 0)) {
+		t.Errorf("modified at %v", a.Modified)
+	}
+	if a.BlockCount != 1<<64-1 || a.AccountVersion != 2 || a.Pending != nil {
+		t.Errorf("account %+v", a)
+	}
+
+	for field, value := range map[string]string{
+		"balance":            "340282366920938463463374607431768211456",
+		"pending":            "-1",
+		"weight":             "1e30",
+		"confirmed_balance":  "0x10",
+		"modified_timestamp": "yesterday",
+		"block_count":        "18446744073709551616",
+		"confirmed_height":   "1.0",
+	} {
+		data, _ := json.Marshal(map[string]string{field: value})
+
+		var typeErr *json.UnmarshalTypeError
+		if err := json.Unmarshal(data, new(rpc.Account)); !errors.As(err, &typeErr) || typeErr.Field != field {
+			t.Errorf("%s of %q returned %v, want an UnmarshalTypeError of the field", field, value, err)
+		}
+	}
+
+	// Through the client, they fail as malformed responses.
+	n := rpctest.NewNode()
+	defer n.Close()
+	n.OnRequest(reply(`{"frontier": "F", "balance": "-1", "block_count": "1"}`))
+	c := n.Client()
+	if _, err := c.AccountInfo(context.Background(), badAccount, false, false, false, false); !errors.Is(err, rpc.ErrMalformedResponse) {
+		t.Errorf("negative balance returned %v, want ErrMalformedResponse", err)
+	}
+}
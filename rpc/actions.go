@@ -0,0 +1,87 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+// The wrappers of most actions, their request and response types and
+// the package level functions calling them are generated from the
+// spec in actions.json. Edit the spec rather than actions_gen.go.
+//go:generate go run ./internal/rpcgen -spec actions.json -o actions_gen.go -default default_gen.go
+
+// Flag is a boolean field of a response. Nodes report them as "1" or
+// "0", or as a "success" key whose mere presence means true.
+type Flag bool
+
+func (f *Flag) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var b bool
+		if err = json.Unmarshal(data, &b); err != nil {
+			return err
+		}
+
+		*f = Flag(b)
+		return nil
+	}
+
+	*f = s == "" || s == "1" || s == "true"
+
+	return nil
+}
+
+// List is a list field of a response. Nodes send an empty
+// string instead of an empty list.
+type List[T any] []T
+
+func (l *List[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte(`""`)) {
+		*l = List[T]{}
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]T)(l))
+}
+
+// Map is an object field of a response. Nodes send an empty
+// string instead of an empty object.
+type Map[V any] map[string]V
+
+func (m *Map[V]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte(`""`)) {
+		*m = Map[V]{}
+		return nil
+	}
+
+	return json.Unmarshal(data, (*map[string]V)(m))
+}
+
+// Decodes the response of action into out, checking that
+// it has every key of required.
+func (c *Client) fetch(ctx context.Context, action string, payload map[string]interface{}, out interface{}, required ...string) error {
+	raw, err := c.call(ctx, action, payload)
+	if err != nil {
+		return err
+	}
+
+	if len(required) > 0 {
+		var keys map[string]json.RawMessage
+		if err = json.Unmarshal(raw, &keys); err != nil {
+			return malformed(action, err)
+		}
+
+		for _, key := range required {
+			if _, ok := keys[key]; !ok {
+				return errMissingKey(action, key)
+			}
+		}
+	}
+
+	if err = json.Unmarshal(raw, out); err != nil {
+		return malformed(action, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,2360 @@
+// Code generated by rpcgen from actions.json. DO NOT EDIT.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// CreateAccountRequest holds the params of account_create, for use with Call.
+type CreateAccountRequest struct {
+	Wallet string `json:"wallet"`
+	Work   bool   `json:"work"`
+}
+
+// CreateAccountResponse holds the response of account_create.
+type CreateAccountResponse struct {
+	Account string `json:"account"`
+}
+
+// Creates a new account, insert next deterministic key in wallet.
+// If work is false, it disables work generation after creating account (>= v8.1).
+// Requires enable_control.
+func (c *Client) CreateAccount(ctx context.Context, wallet string, work bool) (string, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+		"work":   work,
+	}
+
+	var r CreateAccountResponse
+	if err := c.fetch(ctx, "account_create", payload, &r, "account"); err != nil {
+		return "", err
+	}
+
+	return r.Account, nil
+}
+
+// GetAccountRequest holds the params of account_get, for use with Call.
+type GetAccountRequest struct {
+	Key string `json:"key"`
+}
+
+// GetAccountResponse holds the response of account_get.
+type GetAccountResponse struct {
+	Account string `json:"account"`
+}
+
+// Returns account number corresponding to the public key.
+func (c *Client) GetAccount(ctx context.Context, key string) (string, error) {
+	payload := map[string]interface{}{
+		"key": key,
+	}
+
+	var r GetAccountResponse
+	if err := c.fetch(ctx, "account_get", payload, &r, "account"); err != nil {
+		return "", err
+	}
+
+	return r.Account, nil
+}
+
+// AccountInfoRequest holds the params of account_info, for use with Call.
+type AccountInfoRequest struct {
+	Account          string `json:"account"`
+	Representative   bool   `json:"representative"`
+	Weight           bool   `json:"weight"`
+	Pending          bool   `json:"pending"`
+	IncludeConfirmed bool   `json:"include_confirmed,omitempty"`
+}
+
+// AccountInfoResponse holds the response of account_info.
+type AccountInfoResponse struct {
+	Frontier                   string `json:"frontier"`
+	OpenBlock                  string `json:"open_block"`
+	RepresentativeBlock        string `json:"representative_block"`
+	Balance                    string `json:"balance"`
+	ModifiedTimestamp          string `json:"modified_timestamp"`
+	BlockCount                 string `json:"block_count"`
+	AccountVersion             string `json:"account_version,omitempty"`
+	ConfirmationHeight         string `json:"confirmation_height,omitempty"`
+	ConfirmationHeightFrontier string `json:"confirmation_height_frontier,omitempty"`
+	Representative             string `json:"representative,omitempty"`
+	Weight                     string `json:"weight,omitempty"`
+	Pending                    string `json:"pending,omitempty"`
+	ConfirmedBalance           string `json:"confirmed_balance,omitempty"`
+	ConfirmedHeight            string `json:"confirmed_height,omitempty"`
+	ConfirmedFrontier          string `json:"confirmed_frontier,omitempty"`
+	ConfirmedRepresentative    string `json:"confirmed_representative,omitempty"`
+	ConfirmedPending           string `json:"confirmed_pending,omitempty"`
+}
+
+// AccountBalanceRequest holds the params of account_balance, for use with Call.
+type AccountBalanceRequest struct {
+	Account string `json:"account"`
+}
+
+// AccountBalanceResponse holds the response of account_balance.
+type AccountBalanceResponse struct {
+	Balance string `json:"balance"`
+	Pending string `json:"pending"`
+}
+
+// Returns how many RAW is owned (balance) and how many
+// have not yet been received by account (pending).
+func (c *Client) AccountBalance(ctx context.Context, account string) (string, string, error) {
+	payload := map[string]interface{}{
+		"account": account,
+	}
+
+	var r AccountBalanceResponse
+	if err := c.fetch(ctx, "account_balance", payload, &r, "balance", "pending"); err != nil {
+		return "", "", err
+	}
+
+	return r.Balance, r.Pending, nil
+}
+
+// AccountBlockCountRequest holds the params of account_block_count, for use with Call.
+type AccountBlockCountRequest struct {
+	Account string `json:"account"`
+}
+
+// AccountBlockCountResponse holds the response of account_block_count.
+type AccountBlockCountResponse struct {
+	BlockCount int `json:"block_count,string"`
+}
+
+// Returns number of blocks for a specific account.
+func (c *Client) AccountBlockCount(ctx context.Context, account string) (int, error) {
+	payload := map[string]interface{}{
+		"account": account,
+	}
+
+	var r AccountBlockCountResponse
+	if err := c.fetch(ctx, "account_block_count", payload, &r, "block_count"); err != nil {
+		return 0, err
+	}
+
+	return r.BlockCount, nil
+}
+
+// AccountHistoryRequest holds the params of account_history, for use with Call.
+type AccountHistoryRequest struct {
+	Account string `json:"account"`
+	Count   int    `json:"count"`
+	Head    string `json:"head,omitempty"`
+}
+
+// AccountHistoryResponse holds the response of account_history.
+type AccountHistoryResponse struct {
+	Account  string             `json:"account,omitempty"`
+	History  List[HistoryEntry] `json:"history"`
+	Previous string             `json:"previous,omitempty"`
+}
+
+// AccountKeyRequest holds the params of account_key, for use with Call.
+type AccountKeyRequest struct {
+	Account string `json:"account"`
+}
+
+// AccountKeyResponse holds the response of account_key.
+type AccountKeyResponse struct {
+	Key string `json:"key"`
+}
+
+// Returns the public key for account.
+func (c *Client) AccountKey(ctx context.Context, account string) (string, error) {
+	payload := map[string]interface{}{
+		"account": account,
+	}
+
+	var r AccountKeyResponse
+	if err := c.fetch(ctx, "account_key", payload, &r, "key"); err != nil {
+		return "", err
+	}
+
+	return r.Key, nil
+}
+
+// AccountRepresentativeRequest holds the params of account_representative, for use with Call.
+type AccountRepresentativeRequest struct {
+	Account string `json:"account"`
+}
+
+// AccountRepresentativeResponse holds the response of account_representative.
+type AccountRepresentativeResponse struct {
+	Representative string `json:"representative"`
+}
+
+// Returns the representative for account.
+func (c *Client) AccountRepresentative(ctx context.Context, account string) (string, error) {
+	payload := map[string]interface{}{
+		"account": account,
+	}
+
+	var r AccountRepresentativeResponse
+	if err := c.fetch(ctx, "account_representative", payload, &r, "representative"); err != nil {
+		return "", err
+	}
+
+	return r.Representative, nil
+}
+
+// SetAccountRepresentativeRequest holds the params of account_representative_set, for use with Call.
+type SetAccountRepresentativeRequest struct {
+	Wallet         string `json:"wallet"`
+	Account        string `json:"account"`
+	Representative string `json:"representative"`
+	Work           string `json:"work,omitempty"`
+}
+
+// SetAccountRepresentativeResponse holds the response of account_representative_set.
+type SetAccountRepresentativeResponse struct {
+	Block string `json:"block"`
+}
+
+// Sets the representative for account in wallet.
+// If provided, uses work value for block from external source (>= v8.1).
+// Returns the change block.
+// Requires enable_control.
+func (c *Client) SetAccountRepresentative(ctx context.Context, wallet, account, representative, work string) (string, error) {
+	payload := map[string]interface{}{
+		"wallet":         wallet,
+		"account":        account,
+		"representative": representative,
+	}
+
+	if work != "" {
+		payload["work"] = work
+	}
+
+	var r SetAccountRepresentativeResponse
+	if err := c.fetch(ctx, "account_representative_set", payload, &r, "block"); err != nil {
+		return "", err
+	}
+
+	return r.Block, nil
+}
+
+// AccountWeightRequest holds the params of account_weight, for use with Call.
+type AccountWeightRequest struct {
+	Account string `json:"account"`
+}
+
+// AccountWeightResponse holds the response of account_weight.
+type AccountWeightResponse struct {
+	Weight string `json:"weight"`
+}
+
+// Returns the voting weight for account.
+func (c *Client) AccountWeight(ctx context.Context, account string) (string, error) {
+	payload := map[string]interface{}{
+		"account": account,
+	}
+
+	var r AccountWeightResponse
+	if err := c.fetch(ctx, "account_weight", payload, &r, "weight"); err != nil {
+		return "", err
+	}
+
+	return r.Weight, nil
+}
+
+// AccountsBalancesRequest holds the params of accounts_balances, for use with Call.
+type AccountsBalancesRequest struct {
+	Accounts []string `json:"accounts"`
+}
+
+// AccountsBalancesResponse holds the response of accounts_balances.
+type AccountsBalancesResponse struct {
+	Balances Map[map[string]string] `json:"balances"`
+}
+
+// Returns how many RAW is owned and
+// how many have not yet been received by accounts list.
+func (c *Client) AccountsBalances(ctx context.Context, accounts []string) (map[string]map[string]string, error) {
+	payload := map[string]interface{}{
+		"accounts": accounts,
+	}
+
+	var r AccountsBalancesResponse
+	if err := c.fetch(ctx, "accounts_balances", payload, &r, "balances"); err != nil {
+		return nil, err
+	}
+
+	return map[string]map[string]string(r.Balances), nil
+}
+
+// AccountsFrontiersRequest holds the params of accounts_frontiers, for use with Call.
+type AccountsFrontiersRequest struct {
+	Accounts []string `json:"accounts"`
+}
+
+// AccountsFrontiersResponse holds the response of accounts_frontiers.
+type AccountsFrontiersResponse struct {
+	Frontiers Map[string] `json:"frontiers"`
+}
+
+// Returns a list of pairs of account and block hash
+// representing the head block for accounts list.
+func (c *Client) AccountsFrontiers(ctx context.Context, accounts []string) (map[string]string, error) {
+	payload := map[string]interface{}{
+		"accounts": accounts,
+	}
+
+	var r AccountsFrontiersResponse
+	if err := c.fetch(ctx, "accounts_frontiers", payload, &r, "frontiers"); err != nil {
+		return nil, err
+	}
+
+	return map[string]string(r.Frontiers), nil
+}
+
+// AccountsPendingRequest holds the params of accounts_pending, for use with Call.
+type AccountsPendingRequest struct {
+	Accounts  []string `json:"accounts"`
+	Count     int      `json:"count"`
+	Threshold string   `json:"threshold,omitempty"`
+	Source    string   `json:"source,omitempty"`
+}
+
+// AccountsPendingResponse holds the response of accounts_pending.
+type AccountsPendingResponse struct {
+	Blocks Map[interface{}] `json:"blocks"`
+}
+
+// Returns a list of block hashes which have not
+// yet been received by these accounts.
+// If threshold is not empty, returns a list of pending
+// block hashes with amount more or equal to threshold (>= v8.0).
+// If source is not empty, returns a list of pending
+// block hashes with amount and source accounts (>= v8.1).
+func (c *Client) AccountsPending(ctx context.Context, accounts []string, count int, threshold, source string) (map[string]interface{}, error) {
+	payload := map[string]interface{}{
+		"accounts": accounts,
+		"count":    count,
+	}
+
+	if threshold != "" {
+		payload["threshold"] = threshold
+	}
+
+	if source != "" {
+		payload["source"] = source
+	}
+
+	var r AccountsPendingResponse
+	if err := c.fetch(ctx, "accounts_pending", payload, &r, "blocks"); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}(r.Blocks), nil
+}
+
+// DelegatorsRequest holds the params of delegators, for use with Call.
+type DelegatorsRequest struct {
+	Account string `json:"account"`
+}
+
+// DelegatorsResponse holds the response of delegators.
+type DelegatorsResponse struct {
+	Delegators Map[string] `json:"delegators"`
+}
+
+// Returns a list of pairs of delegator names given
+// account a representative and its balance (>= v8.0).
+func (c *Client) Delegators(ctx context.Context, account string) (map[string]string, error) {
+	payload := map[string]interface{}{
+		"account": account,
+	}
+
+	var r DelegatorsResponse
+	if err := c.fetch(ctx, "delegators", payload, &r, "delegators"); err != nil {
+		return nil, err
+	}
+
+	return map[string]string(r.Delegators), nil
+}
+
+// DelegatorsCountRequest holds the params of delegators_count, for use with Call.
+type DelegatorsCountRequest struct {
+	Account string `json:"account"`
+}
+
+// DelegatorsCountResponse holds the response of delegators_count.
+type DelegatorsCountResponse struct {
+	Count int `json:"count,string"`
+}
+
+// Get number of delegators for a specific
+// representative account (>= v8.0).
+func (c *Client) DelegatorsCount(ctx context.Context, account string) (int, error) {
+	payload := map[string]interface{}{
+		"account": account,
+	}
+
+	var r DelegatorsCountResponse
+	if err := c.fetch(ctx, "delegators_count", payload, &r, "count"); err != nil {
+		return 0, err
+	}
+
+	return r.Count, nil
+}
+
+// FrontiersRequest holds the params of frontiers, for use with Call.
+type FrontiersRequest struct {
+	Account string `json:"account"`
+	Count   int    `json:"count"`
+}
+
+// FrontiersResponse holds the response of frontiers.
+type FrontiersResponse struct {
+	Frontiers Map[string] `json:"frontiers"`
+}
+
+// Returns a list of pairs of account and block hash
+// representing the head block starting at account up to count.
+func (c *Client) Frontiers(ctx context.Context, account string, count int) (map[string]string, error) {
+	payload := map[string]interface{}{
+		"account": account,
+		"count":   count,
+	}
+
+	var r FrontiersResponse
+	if err := c.fetch(ctx, "frontiers", payload, &r, "frontiers"); err != nil {
+		return nil, err
+	}
+
+	return map[string]string(r.Frontiers), nil
+}
+
+// WaitPaymentRequest holds the params of payment_wait, for use with Call.
+type WaitPaymentRequest struct {
+	Account string `json:"account"`
+	Amount  string `json:"amount"`
+	Timeout int    `json:"timeout"`
+}
+
+// WaitPaymentResponse holds the response of payment_wait.
+type WaitPaymentResponse struct {
+	Status string `json:"status"`
+}
+
+// Waits for payment of 'amount' to arrive in 'account'
+// or until 'timeout' milliseconds have elapsed.
+func (c *Client) WaitPayment(ctx context.Context, account, amount string, timeout int) (string, error) {
+	payload := map[string]interface{}{
+		"account": account,
+		"amount":  amount,
+		"timeout": timeout,
+	}
+
+	var r WaitPaymentResponse
+	if err := c.fetch(ctx, "payment_wait", payload, &r, "status"); err != nil {
+		return "", err
+	}
+
+	return r.Status, nil
+}
+
+// ValidateAccountNumberRequest holds the params of validate_account_number, for use with Call.
+type ValidateAccountNumberRequest struct {
+	Account string `json:"account"`
+}
+
+// ValidateAccountNumberResponse holds the response of validate_account_number.
+type ValidateAccountNumberResponse struct {
+	Valid Flag `json:"valid"`
+}
+
+// Checks whether account is a valid account number.
+func (c *Client) ValidateAccountNumber(ctx context.Context, account string) (bool, error) {
+	payload := map[string]interface{}{
+		"account": account,
+	}
+
+	var r ValidateAccountNumberResponse
+	if err := c.fetch(ctx, "validate_account_number", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Valid), nil
+}
+
+// PendingRequest holds the params of pending, for use with Call.
+type PendingRequest struct {
+	Account   string `json:"account"`
+	Count     int    `json:"count"`
+	Threshold int    `json:"threshold,omitempty"`
+	Source    bool   `json:"source"`
+}
+
+// PendingResponse holds the response of pending.
+type PendingResponse struct {
+	Blocks interface{} `json:"blocks"`
+}
+
+// Returns a list of block hashes which have not
+// yet been received by this account.
+// Optionally returns a list of pending block hashes
+// with amount more or equal to threshold (>= v8.0).
+// Optionally returns a list of pending block hashes
+// with amount and source accounts (>= v8.0).
+func (c *Client) Pending(ctx context.Context, account string, count, threshold int, source bool) (interface{}, error) {
+	payload := map[string]interface{}{
+		"account": account,
+		"count":   count,
+		"source":  source,
+	}
+
+	if threshold > 0 {
+		payload["threshold"] = threshold
+	}
+
+	var r PendingResponse
+	if err := c.fetch(ctx, "pending", payload, &r, "blocks"); err != nil {
+		return nil, err
+	}
+
+	return r.Blocks, nil
+}
+
+// GetWorkRequest holds the params of work_get, for use with Call.
+type GetWorkRequest struct {
+	Wallet  string `json:"wallet"`
+	Account string `json:"account"`
+}
+
+// GetWorkResponse holds the response of work_get.
+type GetWorkResponse struct {
+	Work string `json:"work"`
+}
+
+// Retrieves work for account in wallet (>= v8.0).
+// Requires enable_control.
+func (c *Client) GetWork(ctx context.Context, wallet, account string) (string, error) {
+	payload := map[string]interface{}{
+		"wallet":  wallet,
+		"account": account,
+	}
+
+	var r GetWorkResponse
+	if err := c.fetch(ctx, "work_get", payload, &r, "work"); err != nil {
+		return "", err
+	}
+
+	return r.Work, nil
+}
+
+// SetWorkRequest holds the params of work_set, for use with Call.
+type SetWorkRequest struct {
+	Wallet  string `json:"wallet"`
+	Account string `json:"account"`
+	Work    string `json:"work"`
+}
+
+// SetWorkResponse holds the response of work_set.
+type SetWorkResponse struct {
+	Success Flag `json:"success"`
+}
+
+// Sets work for account in wallet (>= v8.0).
+// Requires enable_control.
+func (c *Client) SetWork(ctx context.Context, wallet, account, work string) (bool, error) {
+	payload := map[string]interface{}{
+		"wallet":  wallet,
+		"account": account,
+		"work":    work,
+	}
+
+	var r SetWorkResponse
+	if err := c.fetch(ctx, "work_set", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Success), nil
+}
+
+// GetBlockRequest holds the params of block, for use with Call.
+type GetBlockRequest struct {
+	Hash      string `json:"hash"`
+	JSONBlock bool   `json:"json_block,omitempty"`
+}
+
+// GetBlockResponse holds the response of block.
+type GetBlockResponse struct {
+	Contents json.RawMessage `json:"contents"`
+}
+
+// BlocksRequest holds the params of blocks, for use with Call.
+type BlocksRequest struct {
+	Hashes []string `json:"hashes"`
+}
+
+// BlocksResponse holds the response of blocks.
+type BlocksResponse struct {
+	Blocks BlockMap `json:"blocks"`
+}
+
+// Retrieves blocks by hash.
+func (c *Client) Blocks(ctx context.Context, hashes []string) (map[string]Block, error) {
+	payload := map[string]interface{}{
+		"hashes": hashes,
+	}
+
+	var r BlocksResponse
+	if err := c.fetch(ctx, "blocks", payload, &r, "blocks"); err != nil {
+		return nil, err
+	}
+
+	return map[string]Block(r.Blocks), nil
+}
+
+// BlocksInfoRequest holds the params of blocks_info, for use with Call.
+type BlocksInfoRequest struct {
+	Hashes  []string `json:"hashes"`
+	Pending bool     `json:"pending"`
+	Source  bool     `json:"source"`
+}
+
+// BlocksInfoResponse holds the response of blocks_info.
+type BlocksInfoResponse struct {
+	Blocks Map[*BlockInfo] `json:"blocks"`
+}
+
+// BlockAccountRequest holds the params of block_account, for use with Call.
+type BlockAccountRequest struct {
+	Hash string `json:"hash"`
+}
+
+// BlockAccountResponse holds the response of block_account.
+type BlockAccountResponse struct {
+	Account string `json:"account"`
+}
+
+// Returns the account containing block.
+func (c *Client) BlockAccount(ctx context.Context, hash string) (string, error) {
+	payload := map[string]interface{}{
+		"hash": hash,
+	}
+
+	var r BlockAccountResponse
+	if err := c.fetch(ctx, "block_account", payload, &r, "account"); err != nil {
+		return "", err
+	}
+
+	return r.Account, nil
+}
+
+// BlockCountRequest holds the params of block_count, for use with Call.
+type BlockCountRequest struct {
+}
+
+// BlockCountResponse holds the response of block_count.
+type BlockCountResponse struct {
+	Count     string `json:"count"`
+	Unchecked string `json:"unchecked"`
+}
+
+// Reports the number of blocks in the ledger
+// and unchecked synchronizing blocks.
+func (c *Client) BlockCount(ctx context.Context) (map[string]string, error) {
+	return c.fetchMap(ctx, "block_count", nil, "")
+}
+
+// BlockCountTypeRequest holds the params of block_count_type, for use with Call.
+type BlockCountTypeRequest struct {
+}
+
+// BlockCountTypeResponse holds the response of block_count_type.
+type BlockCountTypeResponse struct {
+	Send    string `json:"send"`
+	Receive string `json:"receive"`
+	Open    string `json:"open"`
+	Change  string `json:"change"`
+}
+
+// Reports the number of blocks in the ledger
+// by type (send, receive, open, change).
+func (c *Client) BlockCountType(ctx context.Context) (map[string]string, error) {
+	return c.fetchMap(ctx, "block_count_type", nil, "")
+}
+
+// CreateOpenBlockRequest holds the params of block_create, for use with Call.
+type CreateOpenBlockRequest struct {
+	// Always "open".
+	Type           string `json:"type"`
+	Key            string `json:"key"`
+	Account        string `json:"account"`
+	Representative string `json:"representative"`
+	Source         string `json:"source"`
+	Work           string `json:"work,omitempty"`
+}
+
+// CreateOpenBlockResponse holds the response of block_create.
+type CreateOpenBlockResponse struct {
+	Hash  string           `json:"hash"`
+	Block *LegacyOpenBlock `json:"block"`
+}
+
+// Creates a new open block based on input data & signed with private key (>= v8.1).
+// Optionally uses work value for block from external source.
+// Returns the hash of the block and the block.
+// Requires enable_control.
+func (c *Client) CreateOpenBlock(ctx context.Context, key, account, representative, source, work string) (string, *LegacyOpenBlock, error) {
+	payload := map[string]interface{}{
+		"type":           "open",
+		"key":            key,
+		"account":        account,
+		"representative": representative,
+		"source":         source,
+	}
+
+	if work != "" {
+		payload["work"] = work
+	}
+
+	var r CreateOpenBlockResponse
+	if err := c.fetch(ctx, "block_create", payload, &r, "hash", "block"); err != nil {
+		return "", nil, err
+	}
+
+	if r.Block == nil {
+		return "", nil, fmt.Errorf("%w: response of block_create has a null block", ErrMalformedResponse)
+	}
+	r.Block.setHash(r.Hash)
+
+	return r.Hash, r.Block, nil
+}
+
+// CreateReceiveBlockRequest holds the params of block_create, for use with Call.
+type CreateReceiveBlockRequest struct {
+	// Always "receive".
+	Type     string `json:"type"`
+	Wallet   string `json:"wallet"`
+	Account  string `json:"account"`
+	Source   string `json:"source"`
+	Previous string `json:"previous"`
+	Work     string `json:"work,omitempty"`
+}
+
+// CreateReceiveBlockResponse holds the response of block_create.
+type CreateReceiveBlockResponse struct {
+	Hash  string              `json:"hash"`
+	Block *LegacyReceiveBlock `json:"block"`
+}
+
+// Creates a new receive block (>= v8.1).
+// Optionally uses work value for block from external source.
+// Returns the hash of the block and the block.
+// Requires enable_control.
+func (c *Client) CreateReceiveBlock(ctx context.Context, wallet, account, source, previous, work string) (string, *LegacyReceiveBlock, error) {
+	payload := map[string]interface{}{
+		"type":     "receive",
+		"wallet":   wallet,
+		"account":  account,
+		"source":   source,
+		"previous": previous,
+	}
+
+	if work != "" {
+		payload["work"] = work
+	}
+
+	var r CreateReceiveBlockResponse
+	if err := c.fetch(ctx, "block_create", payload, &r, "hash", "block"); err != nil {
+		return "", nil, err
+	}
+
+	if r.Block == nil {
+		return "", nil, fmt.Errorf("%w: response of block_create has a null block", ErrMalformedResponse)
+	}
+	r.Block.setHash(r.Hash)
+
+	return r.Hash, r.Block, nil
+}
+
+// CreateSendBlockRequest holds the params of block_create, for use with Call.
+type CreateSendBlockRequest struct {
+	// Always "send".
+	Type        string `json:"type"`
+	Wallet      string `json:"wallet"`
+	Account     string `json:"account"`
+	Destination string `json:"destination"`
+	Balance     string `json:"balance"`
+	Amount      string `json:"amount"`
+	Previous    string `json:"previous"`
+	Work        string `json:"work,omitempty"`
+}
+
+// CreateSendBlockResponse holds the response of block_create.
+type CreateSendBlockResponse struct {
+	Hash  string           `json:"hash"`
+	Block *LegacySendBlock `json:"block"`
+}
+
+// Creates a new send block (>= v8.1).
+// Optionally uses work value for block from external source.
+// Returns the hash of the block and the block.
+// Requires enable_control.
+func (c *Client) CreateSendBlock(ctx context.Context, wallet, account, destination, balance, amount, previous, work string) (string, *LegacySendBlock, error) {
+	payload := map[string]interface{}{
+		"type":        "send",
+		"wallet":      wallet,
+		"account":     account,
+		"destination": destination,
+		"balance":     balance,
+		"amount":      amount,
+		"previous":    previous,
+	}
+
+	if work != "" {
+		payload["work"] = work
+	}
+
+	var r CreateSendBlockResponse
+	if err := c.fetch(ctx, "block_create", payload, &r, "hash", "block"); err != nil {
+		return "", nil, err
+	}
+
+	if r.Block == nil {
+		return "", nil, fmt.Errorf("%w: response of block_create has a null block", ErrMalformedResponse)
+	}
+	r.Block.setHash(r.Hash)
+
+	return r.Hash, r.Block, nil
+}
+
+// CreateChangeBlockRequest holds the params of block_create, for use with Call.
+type CreateChangeBlockRequest struct {
+	// Always "change".
+	Type           string `json:"type"`
+	Wallet         string `json:"wallet"`
+	Account        string `json:"account"`
+	Representative string `json:"representative"`
+	Previous       string `json:"previous"`
+	Work           string `json:"work,omitempty"`
+}
+
+// CreateChangeBlockResponse holds the response of block_create.
+type CreateChangeBlockResponse struct {
+	Hash  string             `json:"hash"`
+	Block *LegacyChangeBlock `json:"block"`
+}
+
+// Creates a new change block (>= v8.1).
+// Optionally uses work value for block from external source.
+// Returns the hash of the block and the block.
+// Requires enable_control.
+func (c *Client) CreateChangeBlock(ctx context.Context, wallet, account, representative, previous, work string) (string, *LegacyChangeBlock, error) {
+	payload := map[string]interface{}{
+		"type":           "change",
+		"wallet":         wallet,
+		"account":        account,
+		"representative": representative,
+		"previous":       previous,
+	}
+
+	if work != "" {
+		payload["work"] = work
+	}
+
+	var r CreateChangeBlockResponse
+	if err := c.fetch(ctx, "block_create", payload, &r, "hash", "block"); err != nil {
+		return "", nil, err
+	}
+
+	if r.Block == nil {
+		return "", nil, fmt.Errorf("%w: response of block_create has a null block", ErrMalformedResponse)
+	}
+	r.Block.setHash(r.Hash)
+
+	return r.Hash, r.Block, nil
+}
+
+// CreateStateBlockRequest holds the params of block_create, for use with Call.
+type CreateStateBlockRequest struct {
+	// Always "state".
+	Type           string `json:"type"`
+	Wallet         string `json:"wallet,omitempty"`
+	Account        string `json:"account,omitempty"`
+	Key            string `json:"key,omitempty"`
+	Previous       string `json:"previous"`
+	Representative string `json:"representative"`
+	Balance        string `json:"balance"`
+	Link           string `json:"link"`
+	Work           string `json:"work,omitempty"`
+	JSONBlock      bool   `json:"json_block,omitempty"`
+}
+
+// CreateStateBlockResponse holds the response of block_create.
+type CreateStateBlockResponse struct {
+	Hash  string      `json:"hash"`
+	Block *StateBlock `json:"block"`
+}
+
+// ProcessBlockRequest holds the params of process, for use with Call.
+type ProcessBlockRequest struct {
+	Block     string `json:"block"`
+	Subtype   string `json:"subtype,omitempty"`
+	Force     bool   `json:"force,omitempty"`
+	Async     bool   `json:"async,omitempty"`
+	JSONBlock bool   `json:"json_block,omitempty"`
+}
+
+// ProcessBlockResponse holds the response of process.
+type ProcessBlockResponse struct {
+	Hash    string `json:"hash,omitempty"`
+	Started Flag   `json:"started,omitempty"`
+}
+
+// PendingExistsRequest holds the params of pending_exists, for use with Call.
+type PendingExistsRequest struct {
+	Hash string `json:"hash"`
+}
+
+// PendingExistsResponse holds the response of pending_exists.
+type PendingExistsResponse struct {
+	Exists Flag `json:"exists"`
+}
+
+// Checks whether block is pending by hash (>= v8.0).
+func (c *Client) PendingExists(ctx context.Context, hash string) (bool, error) {
+	payload := map[string]interface{}{
+		"hash": hash,
+	}
+
+	var r PendingExistsResponse
+	if err := c.fetch(ctx, "pending_exists", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Exists), nil
+}
+
+// GetUncheckedBlockRequest holds the params of unchecked_get, for use with Call.
+type GetUncheckedBlockRequest struct {
+	Hash string `json:"hash"`
+}
+
+// GetUncheckedBlockResponse holds the response of unchecked_get.
+type GetUncheckedBlockResponse struct {
+	Contents json.RawMessage `json:"contents"`
+}
+
+// CancelWorkRequest holds the params of work_cancel, for use with Call.
+type CancelWorkRequest struct {
+	Hash string `json:"hash"`
+}
+
+// CancelWorkResponse holds the response of work_cancel.
+type CancelWorkResponse struct {
+}
+
+// Stops generating work for block.
+// Requires enable_control.
+func (c *Client) CancelWork(ctx context.Context, hash string) error {
+	payload := map[string]interface{}{
+		"hash": hash,
+	}
+
+	_, err := c.call(ctx, "work_cancel", payload)
+
+	return err
+}
+
+// GenerateWorkRequest holds the params of work_generate, for use with Call.
+type GenerateWorkRequest struct {
+	Hash string `json:"hash"`
+}
+
+// GenerateWorkResponse holds the response of work_generate.
+type GenerateWorkResponse struct {
+	Work string `json:"work"`
+}
+
+// Generates work for block.
+// Requires enable_control.
+func (c *Client) GenerateWork(ctx context.Context, hash string) (string, error) {
+	payload := map[string]interface{}{
+		"hash": hash,
+	}
+
+	var r GenerateWorkResponse
+	if err := c.fetch(ctx, "work_generate", payload, &r, "work"); err != nil {
+		return "", err
+	}
+
+	return r.Work, nil
+}
+
+// ValidateWorkRequest holds the params of work_validate, for use with Call.
+type ValidateWorkRequest struct {
+	Work string `json:"work"`
+	Hash string `json:"hash"`
+}
+
+// ValidateWorkResponse holds the response of work_validate.
+type ValidateWorkResponse struct {
+	Valid Flag `json:"valid"`
+}
+
+// Checks whether work is valid for block.
+func (c *Client) ValidateWork(ctx context.Context, work, hash string) (bool, error) {
+	payload := map[string]interface{}{
+		"work": work,
+		"hash": hash,
+	}
+
+	var r ValidateWorkResponse
+	if err := c.fetch(ctx, "work_validate", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Valid), nil
+}
+
+// SuccessorsRequest holds the params of successors, for use with Call.
+type SuccessorsRequest struct {
+	Block string `json:"block"`
+	Count int    `json:"count"`
+}
+
+// SuccessorsResponse holds the response of successors.
+type SuccessorsResponse struct {
+	Blocks List[string] `json:"blocks"`
+}
+
+// Returns a list of block hashes in the account
+// chain ending at block up to count.
+func (c *Client) Successors(ctx context.Context, block string, count int) ([]string, error) {
+	payload := map[string]interface{}{
+		"block": block,
+		"count": count,
+	}
+
+	var r SuccessorsResponse
+	if err := c.fetch(ctx, "successors", payload, &r, "blocks"); err != nil {
+		return nil, err
+	}
+
+	return []string(r.Blocks), nil
+}
+
+// ChainRequest holds the params of chain, for use with Call.
+type ChainRequest struct {
+	Block string `json:"block"`
+	Count int    `json:"count"`
+}
+
+// ChainResponse holds the response of chain.
+type ChainResponse struct {
+	Blocks List[string] `json:"blocks"`
+}
+
+// Returns a list of block hashes in the account
+// chain starting at block up to count.
+func (c *Client) Chain(ctx context.Context, block string, count int) ([]string, error) {
+	payload := map[string]interface{}{
+		"block": block,
+		"count": count,
+	}
+
+	var r ChainResponse
+	if err := c.fetch(ctx, "chain", payload, &r, "blocks"); err != nil {
+		return nil, err
+	}
+
+	return []string(r.Blocks), nil
+}
+
+// HistoryRequest holds the params of history, for use with Call.
+type HistoryRequest struct {
+	Hash  string `json:"hash"`
+	Count int    `json:"count"`
+}
+
+// HistoryResponse holds the response of history.
+type HistoryResponse struct {
+	History List[map[string]string] `json:"history"`
+}
+
+// Reports send/receive information for a chain of blocks.
+func (c *Client) History(ctx context.Context, hash string, count int) ([]map[string]string, error) {
+	payload := map[string]interface{}{
+		"hash":  hash,
+		"count": count,
+	}
+
+	var r HistoryResponse
+	if err := c.fetch(ctx, "history", payload, &r, "history"); err != nil {
+		return nil, err
+	}
+
+	return []map[string]string(r.History), nil
+}
+
+// AvailableSupplyRequest holds the params of available_supply, for use with Call.
+type AvailableSupplyRequest struct {
+}
+
+// AvailableSupplyResponse holds the response of available_supply.
+type AvailableSupplyResponse struct {
+	Available string `json:"available"`
+}
+
+// Returns how many rai are in the public supply.
+func (c *Client) AvailableSupply(ctx context.Context) (string, error) {
+	var r AvailableSupplyResponse
+	if err := c.fetch(ctx, "available_supply", nil, &r, "available"); err != nil {
+		return "", err
+	}
+
+	return r.Available, nil
+}
+
+// FrontierCountRequest holds the params of frontier_count, for use with Call.
+type FrontierCountRequest struct {
+}
+
+// FrontierCountResponse holds the response of frontier_count.
+type FrontierCountResponse struct {
+	Count int `json:"count,string"`
+}
+
+// Reports the number of accounts in the ledger.
+func (c *Client) FrontierCount(ctx context.Context) (int, error) {
+	var r FrontierCountResponse
+	if err := c.fetch(ctx, "frontier_count", nil, &r, "count"); err != nil {
+		return 0, err
+	}
+
+	return r.Count, nil
+}
+
+// RepresentativesRequest holds the params of representatives, for use with Call.
+type RepresentativesRequest struct {
+	Count   int  `json:"count,omitempty"`
+	Sorting bool `json:"sorting"`
+}
+
+// RepresentativesResponse holds the response of representatives.
+type RepresentativesResponse struct {
+	Representatives Map[string] `json:"representatives"`
+}
+
+// Returns a map of representatives and their voting weights.
+// If count > 0, limits the number of representatives returned.
+// Optionally sorts representatives in descending order.
+func (c *Client) Representatives(ctx context.Context, count int, sorting bool) (map[string]string, error) {
+	payload := map[string]interface{}{
+		"sorting": sorting,
+	}
+
+	if count > 0 {
+		payload["count"] = count
+	}
+
+	var r RepresentativesResponse
+	if err := c.fetch(ctx, "representatives", payload, &r, "representatives"); err != nil {
+		return nil, err
+	}
+
+	return map[string]string(r.Representatives), nil
+}
+
+// LedgerRequest holds the params of ledger, for use with Call.
+type LedgerRequest struct {
+	Account        string `json:"account"`
+	Count          int    `json:"count"`
+	Representative bool   `json:"representative"`
+	Weight         bool   `json:"weight"`
+	Pending        bool   `json:"pending"`
+	Sorting        bool   `json:"sorting"`
+}
+
+// LedgerResponse holds the response of ledger.
+type LedgerResponse struct {
+	Accounts Map[*Account] `json:"accounts"`
+}
+
+// Returns frontier, open block, change representative block,
+// balance, last modified timestamp from local database and
+// block count starting at account up to count (>= v8.1).
+// Optionally returns representative, voting weight,
+// pending balance for each account.
+// Optionally sorts accounts in descending order.
+// Requires enable_control.
+func (c *Client) Ledger(ctx context.Context, account string, count int, representative, weight, pending, sorting bool) (map[string]*Account, error) {
+	payload := map[string]interface{}{
+		"account":        account,
+		"count":          count,
+		"representative": representative,
+		"weight":         weight,
+		"pending":        pending,
+		"sorting":        sorting,
+	}
+
+	var r LedgerResponse
+	if err := c.fetch(ctx, "ledger", payload, &r, "accounts"); err != nil {
+		return nil, err
+	}
+
+	return map[string]*Account(r.Accounts), nil
+}
+
+// GetReceiveMinimumRequest holds the params of receive_minimum, for use with Call.
+type GetReceiveMinimumRequest struct {
+}
+
+// GetReceiveMinimumResponse holds the response of receive_minimum.
+type GetReceiveMinimumResponse struct {
+	Amount string `json:"amount"`
+}
+
+// Returns receive minimum for node (>= v8.0).
+// Requires enable_control.
+func (c *Client) GetReceiveMinimum(ctx context.Context) (string, error) {
+	var r GetReceiveMinimumResponse
+	if err := c.fetch(ctx, "receive_minimum", nil, &r, "amount"); err != nil {
+		return "", err
+	}
+
+	return r.Amount, nil
+}
+
+// SetReceiveMinimumRequest holds the params of receive_minimum_set, for use with Call.
+type SetReceiveMinimumRequest struct {
+	Amount string `json:"amount"`
+}
+
+// SetReceiveMinimumResponse holds the response of receive_minimum_set.
+type SetReceiveMinimumResponse struct {
+	Success Flag `json:"success"`
+}
+
+// Sets amount as new receive minimum for node until restart (>= v8.0).
+// Returns true if minimum receive was successfully set.
+// Requires enable_control.
+func (c *Client) SetReceiveMinimum(ctx context.Context, amount string) (bool, error) {
+	payload := map[string]interface{}{
+		"amount": amount,
+	}
+
+	var r SetReceiveMinimumResponse
+	if err := c.fetch(ctx, "receive_minimum_set", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Success), nil
+}
+
+// SearchAllPendingRequest holds the params of search_pending_all, for use with Call.
+type SearchAllPendingRequest struct {
+}
+
+// SearchAllPendingResponse holds the response of search_pending_all.
+type SearchAllPendingResponse struct {
+	Success Flag `json:"success"`
+}
+
+// Tells the node to look for pending blocks for any account in all
+// available wallets (>= v8.0).
+// Returns true if search started successfully, and false otherwise.
+// Requires enable_control.
+func (c *Client) SearchAllPending(ctx context.Context) (bool, error) {
+	var r SearchAllPendingResponse
+	if err := c.fetch(ctx, "search_pending_all", nil, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Success), nil
+}
+
+// UncheckedBlocksRequest holds the params of unchecked, for use with Call.
+type UncheckedBlocksRequest struct {
+	Count int `json:"count"`
+}
+
+// UncheckedBlocksResponse holds the response of unchecked.
+type UncheckedBlocksResponse struct {
+	Blocks BlockMap `json:"blocks"`
+}
+
+// Returns a map of unchecked synchronizing block hashes and their
+// blocks up to count (>= v8.0).
+func (c *Client) UncheckedBlocks(ctx context.Context, count int) (map[string]Block, error) {
+	payload := map[string]interface{}{
+		"count": count,
+	}
+
+	var r UncheckedBlocksResponse
+	if err := c.fetch(ctx, "unchecked", payload, &r, "blocks"); err != nil {
+		return nil, err
+	}
+
+	return map[string]Block(r.Blocks), nil
+}
+
+// ClearUncheckedBlocksRequest holds the params of unchecked_clear, for use with Call.
+type ClearUncheckedBlocksRequest struct {
+}
+
+// ClearUncheckedBlocksResponse holds the response of unchecked_clear.
+type ClearUncheckedBlocksResponse struct {
+	Success Flag `json:"success"`
+}
+
+// Clears unchecked synchronizing blocks (>= v8.0).
+// Returns true if successfully cleared.
+// Requires enable_control.
+func (c *Client) ClearUncheckedBlocks(ctx context.Context) (bool, error) {
+	var r ClearUncheckedBlocksResponse
+	if err := c.fetch(ctx, "unchecked_clear", nil, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Success), nil
+}
+
+// UncheckedKeysRequest holds the params of unchecked_keys, for use with Call.
+type UncheckedKeysRequest struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+// UncheckedKeysResponse holds the response of unchecked_keys.
+type UncheckedKeysResponse struct {
+	Unchecked List[UncheckedEntry] `json:"unchecked"`
+}
+
+// SendKeepaliveRequest holds the params of keepalive, for use with Call.
+type SendKeepaliveRequest struct {
+	Address string `json:"address"`
+	Port    int    `json:"port"`
+}
+
+// SendKeepaliveResponse holds the response of keepalive.
+type SendKeepaliveResponse struct {
+}
+
+// Tells the node to send a keepalive packet to address:port.
+// Requires enable_control.
+func (c *Client) SendKeepalive(ctx context.Context, address string, port int) error {
+	payload := map[string]interface{}{
+		"address": address,
+		"port":    port,
+	}
+
+	_, err := c.call(ctx, "keepalive", payload)
+
+	return err
+}
+
+// PeersRequest holds the params of peers, for use with Call.
+type PeersRequest struct {
+}
+
+// PeersResponse holds the response of peers.
+type PeersResponse struct {
+	Peers Map[string] `json:"peers"`
+}
+
+// Returns a map of peer addresses (IPv6:port) and their node network versions.
+func (c *Client) Peers(ctx context.Context) (map[string]string, error) {
+	var r PeersResponse
+	if err := c.fetch(ctx, "peers", nil, &r, "peers"); err != nil {
+		return nil, err
+	}
+
+	return map[string]string(r.Peers), nil
+}
+
+// AddWorkPeerRequest holds the params of work_peer_add, for use with Call.
+type AddWorkPeerRequest struct {
+	Address string `json:"address"`
+	Port    string `json:"port"`
+}
+
+// AddWorkPeerResponse holds the response of work_peer_add.
+type AddWorkPeerResponse struct {
+	Success Flag `json:"success"`
+}
+
+// Adds a specific IP address and port as work peer for node until restart (>= v8.0).
+// Returns true if work peer was added successfully.
+// Requires enable_control.
+func (c *Client) AddWorkPeer(ctx context.Context, address, port string) (bool, error) {
+	payload := map[string]interface{}{
+		"address": address,
+		"port":    port,
+	}
+
+	var r AddWorkPeerResponse
+	if err := c.fetch(ctx, "work_peer_add", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Success), nil
+}
+
+// GetWorkPeersRequest holds the params of work_peers, for use with Call.
+type GetWorkPeersRequest struct {
+}
+
+// GetWorkPeersResponse holds the response of work_peers.
+type GetWorkPeersResponse struct {
+	WorkPeers List[string] `json:"work_peers"`
+}
+
+// Retrieves work peers (>= v8.0).
+// Requires enable_control.
+func (c *Client) GetWorkPeers(ctx context.Context) ([]string, error) {
+	var r GetWorkPeersResponse
+	if err := c.fetch(ctx, "work_peers", nil, &r, "work_peers"); err != nil {
+		return nil, err
+	}
+
+	return []string(r.WorkPeers), nil
+}
+
+// ClearWorkPeersRequest holds the params of work_peers_clear, for use with Call.
+type ClearWorkPeersRequest struct {
+}
+
+// ClearWorkPeersResponse holds the response of work_peers_clear.
+type ClearWorkPeersResponse struct {
+	Success Flag `json:"success"`
+}
+
+// Clears work peers node list until restart (>= v8.0).
+// Requires enable_control.
+func (c *Client) ClearWorkPeers(ctx context.Context) (bool, error) {
+	var r ClearWorkPeersResponse
+	if err := c.fetch(ctx, "work_peers_clear", nil, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Success), nil
+}
+
+// BootstrapRequest holds the params of bootstrap, for use with Call.
+type BootstrapRequest struct {
+	Address string `json:"address"`
+	Port    int    `json:"port"`
+}
+
+// BootstrapResponse holds the response of bootstrap.
+type BootstrapResponse struct {
+	Success Flag `json:"success"`
+}
+
+// Initializes bootstrap to specific IP address and port.
+// Returns true if bootstrap was started successfully.
+func (c *Client) Bootstrap(ctx context.Context, address string, port int) (bool, error) {
+	payload := map[string]interface{}{
+		"address": address,
+		"port":    port,
+	}
+
+	var r BootstrapResponse
+	if err := c.fetch(ctx, "bootstrap", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Success), nil
+}
+
+// BootstrapAnyRequest holds the params of bootstrap_any, for use with Call.
+type BootstrapAnyRequest struct {
+}
+
+// BootstrapAnyResponse holds the response of bootstrap_any.
+type BootstrapAnyResponse struct {
+	Success Flag `json:"success"`
+}
+
+// Initialize multi-connection bootstrap to random peers.
+// Returns true if bootstrap was started successfully.
+func (c *Client) BootstrapAny(ctx context.Context) (bool, error) {
+	var r BootstrapAnyResponse
+	if err := c.fetch(ctx, "bootstrap_any", nil, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Success), nil
+}
+
+// RepublishRequest holds the params of republish, for use with Call.
+type RepublishRequest struct {
+	Hash         string `json:"hash"`
+	Count        int    `json:"count,omitempty"`
+	Sources      int    `json:"sources,omitempty"`
+	Destinations int    `json:"destinations,omitempty"`
+}
+
+// RepublishResponse holds the response of republish.
+type RepublishResponse struct {
+	Blocks List[string] `json:"blocks"`
+}
+
+// Rebroadcasts blocks starting at hash to the network.
+// If sources > 0, additionally rebroadcast source
+// chain blocks for receive/open up to sources depth (>= v8.0).
+// If destinations > 0, additionally rebroadcast destination
+// chain blocks from receive up to destinations depth (>= v8.0).
+func (c *Client) Republish(ctx context.Context, hash string, count, sources, destinations int) ([]string, error) {
+	payload := map[string]interface{}{
+		"hash": hash,
+	}
+
+	if count > 0 {
+		payload["count"] = count
+	}
+
+	if sources > 0 {
+		payload["sources"] = sources
+	}
+
+	if destinations > 0 {
+		payload["destinations"] = destinations
+	}
+
+	var r RepublishResponse
+	if err := c.fetch(ctx, "republish", payload, &r, "blocks"); err != nil {
+		return nil, err
+	}
+
+	return []string(r.Blocks), nil
+}
+
+// VersionRequest holds the params of version, for use with Call.
+type VersionRequest struct {
+}
+
+// VersionResponse holds the response of version.
+type VersionResponse struct {
+	RPCVersion      string `json:"rpc_version"`
+	StoreVersion    string `json:"store_version"`
+	ProtocolVersion string `json:"protocol_version,omitempty"`
+	NodeVendor      string `json:"node_vendor"`
+}
+
+// Returns version information for RPC, Store & Node (Major & Minor version).
+// RPC Version always retruns "1" as of 13/01/2018.
+func (c *Client) Version(ctx context.Context) (map[string]string, error) {
+	return c.fetchMap(ctx, "version", nil, "")
+}
+
+// StopRequest holds the params of stop, for use with Call.
+type StopRequest struct {
+}
+
+// StopResponse holds the response of stop.
+type StopResponse struct {
+	Success Flag `json:"success"`
+}
+
+// Stops the node safely.
+// Requires enable_control.
+func (c *Client) Stop(ctx context.Context) (bool, error) {
+	var r StopResponse
+	if err := c.fetch(ctx, "stop", nil, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Success), nil
+}
+
+// AccountListRequest holds the params of account_list, for use with Call.
+type AccountListRequest struct {
+	Wallet string `json:"wallet"`
+}
+
+// AccountListResponse holds the response of account_list.
+type AccountListResponse struct {
+	Accounts List[string] `json:"accounts"`
+}
+
+// Lists all the accounts inside wallet.
+func (c *Client) AccountList(ctx context.Context, wallet string) ([]string, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+	}
+
+	var r AccountListResponse
+	if err := c.fetch(ctx, "account_list", payload, &r, "accounts"); err != nil {
+		return nil, err
+	}
+
+	return []string(r.Accounts), nil
+}
+
+// MoveAccountsRequest holds the params of account_move, for use with Call.
+type MoveAccountsRequest struct {
+	Wallet   string   `json:"wallet"`
+	Source   string   `json:"source"`
+	Accounts []string `json:"accounts"`
+}
+
+// MoveAccountsResponse holds the response of account_move.
+type MoveAccountsResponse struct {
+	Moved Flag `json:"moved"`
+}
+
+// Moves accounts from source to wallet.
+// Returns true if accounts were moved successfully.
+// Requires enable_control.
+func (c *Client) MoveAccounts(ctx context.Context, wallet, source string, accounts []string) (bool, error) {
+	payload := map[string]interface{}{
+		"wallet":   wallet,
+		"source":   source,
+		"accounts": accounts,
+	}
+
+	var r MoveAccountsResponse
+	if err := c.fetch(ctx, "account_move", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Moved), nil
+}
+
+// RemoveAccountRequest holds the params of account_remove, for use with Call.
+type RemoveAccountRequest struct {
+	Wallet  string `json:"wallet"`
+	Account string `json:"account"`
+}
+
+// RemoveAccountResponse holds the response of account_remove.
+type RemoveAccountResponse struct {
+	Removed Flag `json:"removed"`
+}
+
+// Removes account from wallet.
+// Returns true if account was removed successfully.
+// Requires enable_control.
+func (c *Client) RemoveAccount(ctx context.Context, wallet, account string) (bool, error) {
+	payload := map[string]interface{}{
+		"wallet":  wallet,
+		"account": account,
+	}
+
+	var r RemoveAccountResponse
+	if err := c.fetch(ctx, "account_remove", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Removed), nil
+}
+
+// CreateAccountsRequest holds the params of accounts_create, for use with Call.
+type CreateAccountsRequest struct {
+	Wallet string `json:"wallet"`
+	Count  int    `json:"count"`
+	Work   bool   `json:"work"`
+}
+
+// CreateAccountsResponse holds the response of accounts_create.
+type CreateAccountsResponse struct {
+	Accounts List[string] `json:"accounts"`
+}
+
+// Creates new accounts, insert next deterministic keys in wallet up to count (>= v8.1).
+// Optionally disables work generation after creating account.
+// Requires enable_control.
+func (c *Client) CreateAccounts(ctx context.Context, wallet string, count int, work bool) ([]string, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+		"count":  count,
+		"work":   work,
+	}
+
+	var r CreateAccountsResponse
+	if err := c.fetch(ctx, "accounts_create", payload, &r, "accounts"); err != nil {
+		return nil, err
+	}
+
+	return []string(r.Accounts), nil
+}
+
+// BeginPaymentRequest holds the params of payment_begin, for use with Call.
+type BeginPaymentRequest struct {
+	Wallet string `json:"wallet"`
+}
+
+// BeginPaymentResponse holds the response of payment_begin.
+type BeginPaymentResponse struct {
+	Account string `json:"account"`
+}
+
+// Begins a new payment session. Searches wallet for an account that's marked
+// as available and has a 0 balance. If one is found, the account number
+// is returned and is marked as unavailable. If no account is found,
+// a new account is created, placed in the wallet, and returned.
+func (c *Client) BeginPayment(ctx context.Context, wallet string) (string, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+	}
+
+	var r BeginPaymentResponse
+	if err := c.fetch(ctx, "payment_begin", payload, &r, "account"); err != nil {
+		return "", err
+	}
+
+	return r.Account, nil
+}
+
+// InitPaymentRequest holds the params of payment_init, for use with Call.
+type InitPaymentRequest struct {
+	Wallet string `json:"wallet"`
+}
+
+// InitPaymentResponse holds the response of payment_init.
+type InitPaymentResponse struct {
+	Status string `json:"status"`
+}
+
+// Marks all accounts in wallet as available for being used as a payment session.
+// Returns status.
+func (c *Client) InitPayment(ctx context.Context, wallet string) (string, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+	}
+
+	var r InitPaymentResponse
+	if err := c.fetch(ctx, "payment_init", payload, &r, "status"); err != nil {
+		return "", err
+	}
+
+	return r.Status, nil
+}
+
+// EndPaymentRequest holds the params of payment_end, for use with Call.
+type EndPaymentRequest struct {
+	Wallet  string `json:"wallet"`
+	Account string `json:"account"`
+}
+
+// EndPaymentResponse holds the response of payment_end.
+type EndPaymentResponse struct {
+}
+
+// Ends a payment session. Marks the account as available for use in a payment session.
+func (c *Client) EndPayment(ctx context.Context, wallet, account string) error {
+	payload := map[string]interface{}{
+		"wallet":  wallet,
+		"account": account,
+	}
+
+	_, err := c.call(ctx, "payment_end", payload)
+
+	return err
+}
+
+// ReceiveBlockRequest holds the params of receive, for use with Call.
+type ReceiveBlockRequest struct {
+	Wallet  string `json:"wallet"`
+	Account string `json:"account"`
+	Block   string `json:"block"`
+	Work    string `json:"work,omitempty"`
+}
+
+// ReceiveBlockResponse holds the response of receive.
+type ReceiveBlockResponse struct {
+	Block string `json:"block"`
+}
+
+// Receives pending block for account in wallet.
+// Optionally Uses work value for block from external source (>= v8.1).
+// Requires enable_control.
+func (c *Client) ReceiveBlock(ctx context.Context, wallet, account, block, work string) (string, error) {
+	payload := map[string]interface{}{
+		"wallet":  wallet,
+		"account": account,
+		"block":   block,
+	}
+
+	if work != "" {
+		payload["work"] = work
+	}
+
+	var r ReceiveBlockResponse
+	if err := c.fetch(ctx, "receive", payload, &r, "block"); err != nil {
+		return "", err
+	}
+
+	return r.Block, nil
+}
+
+// WalletRepresentativeRequest holds the params of wallet_representative, for use with Call.
+type WalletRepresentativeRequest struct {
+	Wallet string `json:"wallet"`
+}
+
+// WalletRepresentativeResponse holds the response of wallet_representative.
+type WalletRepresentativeResponse struct {
+	Representative string `json:"representative"`
+}
+
+// Returns the default representative for wallet.
+func (c *Client) WalletRepresentative(ctx context.Context, wallet string) (string, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+	}
+
+	var r WalletRepresentativeResponse
+	if err := c.fetch(ctx, "wallet_representative", payload, &r, "representative"); err != nil {
+		return "", err
+	}
+
+	return r.Representative, nil
+}
+
+// SetWalletRepresentativeRequest holds the params of wallet_representative_set, for use with Call.
+type SetWalletRepresentativeRequest struct {
+	Wallet         string `json:"wallet"`
+	Representative string `json:"representative"`
+}
+
+// SetWalletRepresentativeResponse holds the response of wallet_representative_set.
+type SetWalletRepresentativeResponse struct {
+	Set Flag `json:"set"`
+}
+
+// Sets the default representative for wallet.
+// Requires enable_control.
+func (c *Client) SetWalletRepresentative(ctx context.Context, wallet, representative string) (bool, error) {
+	payload := map[string]interface{}{
+		"wallet":         wallet,
+		"representative": representative,
+	}
+
+	var r SetWalletRepresentativeResponse
+	if err := c.fetch(ctx, "wallet_representative_set", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Set), nil
+}
+
+// SearchPendingRequest holds the params of search_pending, for use with Call.
+type SearchPendingRequest struct {
+	Wallet string `json:"wallet"`
+}
+
+// SearchPendingResponse holds the response of search_pending.
+type SearchPendingResponse struct {
+	Started Flag `json:"started"`
+}
+
+// Tells the node to look for pending blocks for any account in wallet.
+// Requires enable_control.
+func (c *Client) SearchPending(ctx context.Context, wallet string) (bool, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+	}
+
+	var r SearchPendingResponse
+	if err := c.fetch(ctx, "search_pending", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Started), nil
+}
+
+// SendRequest holds the params of send, for use with Call.
+type SendRequest struct {
+	Wallet      string `json:"wallet"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	ID          string `json:"id"`
+	Amount      int    `json:"amount"`
+	Work        string `json:"work,omitempty"`
+}
+
+// SendResponse holds the response of send.
+type SendResponse struct {
+	Block string `json:"block"`
+}
+
+// WalletAddRequest holds the params of wallet_add, for use with Call.
+type WalletAddRequest struct {
+	Wallet string `json:"wallet"`
+	Key    string `json:"key"`
+	Work   bool   `json:"work"`
+}
+
+// WalletAddResponse holds the response of wallet_add.
+type WalletAddResponse struct {
+	Account string `json:"account"`
+}
+
+// Adds an adhoc private key key to wallet and returns its account.
+// Optionally disables work generation after adding account (>= v8.1).
+// Requires enable_control.
+func (c *Client) WalletAdd(ctx context.Context, wallet, key string, work bool) (string, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+		"key":    key,
+		"work":   work,
+	}
+
+	var r WalletAddResponse
+	if err := c.fetch(ctx, "wallet_add", payload, &r, "account"); err != nil {
+		return "", err
+	}
+
+	return r.Account, nil
+}
+
+// WalletTotalBalanceRequest holds the params of wallet_balance_total, for use with Call.
+type WalletTotalBalanceRequest struct {
+	Wallet string `json:"wallet"`
+}
+
+// WalletTotalBalanceResponse holds the response of wallet_balance_total.
+type WalletTotalBalanceResponse struct {
+	Balance string `json:"balance"`
+	Pending string `json:"pending"`
+}
+
+// Returns the sum of all accounts balances in wallet.
+func (c *Client) WalletTotalBalance(ctx context.Context, wallet string) (map[string]string, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+	}
+
+	return c.fetchMap(ctx, "wallet_balance_total", payload, "")
+}
+
+// WalletBalancesRequest holds the params of wallet_balances, for use with Call.
+type WalletBalancesRequest struct {
+	Wallet    string `json:"wallet"`
+	Threshold int    `json:"threshold,omitempty"`
+}
+
+// WalletBalancesResponse holds the response of wallet_balances.
+type WalletBalancesResponse struct {
+	Balances Map[map[string]string] `json:"balances"`
+}
+
+// Returns how many rai is owned and how many have not
+// yet been received by all accounts in wallet.
+// If threshold > 0, returns wallet accounts balances more or equal to threshold (>= v8.1).
+func (c *Client) WalletBalances(ctx context.Context, wallet string, threshold int) (map[string]map[string]string, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+	}
+
+	if threshold > 0 {
+		payload["threshold"] = threshold
+	}
+
+	var r WalletBalancesResponse
+	if err := c.fetch(ctx, "wallet_balances", payload, &r, "balances"); err != nil {
+		return nil, err
+	}
+
+	return map[string]map[string]string(r.Balances), nil
+}
+
+// ChangeWalletSeedRequest holds the params of wallet_change_seed, for use with Call.
+type ChangeWalletSeedRequest struct {
+	Wallet string `json:"wallet"`
+	Seed   string `json:"seed"`
+}
+
+// ChangeWalletSeedResponse holds the response of wallet_change_seed.
+type ChangeWalletSeedResponse struct {
+	Success Flag `json:"success"`
+}
+
+// Changes seed for wallet to seed.
+// Requires enable_control.
+func (c *Client) ChangeWalletSeed(ctx context.Context, wallet, seed string) (bool, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+		"seed":   seed,
+	}
+
+	var r ChangeWalletSeedResponse
+	if err := c.fetch(ctx, "wallet_change_seed", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Success), nil
+}
+
+// WalletContainsRequest holds the params of wallet_contains, for use with Call.
+type WalletContainsRequest struct {
+	Wallet  string `json:"wallet"`
+	Account string `json:"account"`
+}
+
+// WalletContainsResponse holds the response of wallet_contains.
+type WalletContainsResponse struct {
+	Exists Flag `json:"exists"`
+}
+
+// Checks whether wallet contains account.
+func (c *Client) WalletContains(ctx context.Context, wallet, account string) (bool, error) {
+	payload := map[string]interface{}{
+		"wallet":  wallet,
+		"account": account,
+	}
+
+	var r WalletContainsResponse
+	if err := c.fetch(ctx, "wallet_contains", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Exists), nil
+}
+
+// CreateWalletRequest holds the params of wallet_create, for use with Call.
+type CreateWalletRequest struct {
+}
+
+// CreateWalletResponse holds the response of wallet_create.
+type CreateWalletResponse struct {
+	Wallet string `json:"wallet"`
+}
+
+// Creates a new random wallet id.
+// Requires enable_control.
+func (c *Client) CreateWallet(ctx context.Context) (string, error) {
+	var r CreateWalletResponse
+	if err := c.fetch(ctx, "wallet_create", nil, &r, "wallet"); err != nil {
+		return "", err
+	}
+
+	return r.Wallet, nil
+}
+
+// DestroyWalletRequest holds the params of wallet_destroy, for use with Call.
+type DestroyWalletRequest struct {
+	Wallet string `json:"wallet"`
+}
+
+// DestroyWalletResponse holds the response of wallet_destroy.
+type DestroyWalletResponse struct {
+}
+
+// Destroys wallet and all contained accounts.
+// Requires enable_control.
+func (c *Client) DestroyWallet(ctx context.Context, wallet string) error {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+	}
+
+	_, err := c.call(ctx, "wallet_destroy", payload)
+
+	return err
+}
+
+// ExportWalletRequest holds the params of wallet_export, for use with Call.
+type ExportWalletRequest struct {
+	Wallet string `json:"wallet"`
+}
+
+// ExportWalletResponse holds the response of wallet_export.
+type ExportWalletResponse struct {
+	JSON string `json:"json"`
+}
+
+// Returns a json representation of wallet.
+func (c *Client) ExportWallet(ctx context.Context, wallet string) (string, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+	}
+
+	var r ExportWalletResponse
+	if err := c.fetch(ctx, "wallet_export", payload, &r, "json"); err != nil {
+		return "", err
+	}
+
+	return r.JSON, nil
+}
+
+// WalletFrontiersRequest holds the params of wallet_frontiers, for use with Call.
+type WalletFrontiersRequest struct {
+	Wallet string `json:"wallet"`
+}
+
+// WalletFrontiersResponse holds the response of wallet_frontiers.
+type WalletFrontiersResponse struct {
+	Frontiers Map[string] `json:"frontiers"`
+}
+
+// Returns a list of pairs of account and block hash representing
+// the head block starting for accounts from wallet.
+func (c *Client) WalletFrontiers(ctx context.Context, wallet string) (map[string]string, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+	}
+
+	var r WalletFrontiersResponse
+	if err := c.fetch(ctx, "wallet_frontiers", payload, &r, "frontiers"); err != nil {
+		return nil, err
+	}
+
+	return map[string]string(r.Frontiers), nil
+}
+
+// WalletPendingRequest holds the params of wallet_pending, for use with Call.
+type WalletPendingRequest struct {
+	Wallet    string `json:"wallet"`
+	Count     int    `json:"count"`
+	Threshold int    `json:"threshold,omitempty"`
+	Source    bool   `json:"source"`
+}
+
+// WalletPendingResponse holds the response of wallet_pending.
+type WalletPendingResponse struct {
+	Blocks Map[interface{}] `json:"blocks"`
+}
+
+// Returns a list of block hashes which have not yet been
+// received by accounts in this wallet (>= v8.0).
+// If threshold > 0, Returns a list of pending block hashes
+// with amount more or equal to threshold.
+// Optionally, Returns a list of pending block hashes with
+// amount and source accounts (>= v8.1).
+// Requires enable_control.
+func (c *Client) WalletPending(ctx context.Context, wallet string, count, threshold int, source bool) (map[string]interface{}, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+		"count":  count,
+		"source": source,
+	}
+
+	if threshold > 0 {
+		payload["threshold"] = threshold
+	}
+
+	var r WalletPendingResponse
+	if err := c.fetch(ctx, "wallet_pending", payload, &r, "blocks"); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}(r.Blocks), nil
+}
+
+// WalletRepublishRequest holds the params of wallet_republish, for use with Call.
+type WalletRepublishRequest struct {
+	Wallet string `json:"wallet"`
+	Count  int    `json:"count"`
+}
+
+// WalletRepublishResponse holds the response of wallet_republish.
+type WalletRepublishResponse struct {
+	Blocks List[string] `json:"blocks"`
+}
+
+// Rebroadcasts blocks for accounts from wallet starting
+// at frontier down to count to the network (>= v8.0).
+// Requires enable_control.
+func (c *Client) WalletRepublish(ctx context.Context, wallet string, count int) ([]string, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+		"count":  count,
+	}
+
+	var r WalletRepublishResponse
+	if err := c.fetch(ctx, "wallet_republish", payload, &r, "blocks"); err != nil {
+		return nil, err
+	}
+
+	return []string(r.Blocks), nil
+}
+
+// WalletWorkGetRequest holds the params of wallet_work_get, for use with Call.
+type WalletWorkGetRequest struct {
+	Wallet string `json:"wallet"`
+}
+
+// WalletWorkGetResponse holds the response of wallet_work_get.
+type WalletWorkGetResponse struct {
+	Works Map[string] `json:"works"`
+}
+
+// Returns a map of account and work from wallet (>= v8.0).
+// Requires enable_control.
+func (c *Client) WalletWorkGet(ctx context.Context, wallet string) (map[string]string, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+	}
+
+	var r WalletWorkGetResponse
+	if err := c.fetch(ctx, "wallet_work_get", payload, &r, "works"); err != nil {
+		return nil, err
+	}
+
+	return map[string]string(r.Works), nil
+}
+
+// ChangeWalletPasswordRequest holds the params of password_change, for use with Call.
+type ChangeWalletPasswordRequest struct {
+	Wallet   string `json:"wallet"`
+	Password string `json:"password"`
+}
+
+// ChangeWalletPasswordResponse holds the response of password_change.
+type ChangeWalletPasswordResponse struct {
+	Changed Flag `json:"changed"`
+}
+
+// Changes the password for wallet to password.
+// Requires enable_control.
+func (c *Client) ChangeWalletPassword(ctx context.Context, wallet, password string) (bool, error) {
+	payload := map[string]interface{}{
+		"wallet":   wallet,
+		"password": password,
+	}
+
+	var r ChangeWalletPasswordResponse
+	if err := c.fetch(ctx, "password_change", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Changed), nil
+}
+
+// EnterWalletPasswordRequest holds the params of password_enter, for use with Call.
+type EnterWalletPasswordRequest struct {
+	Wallet   string `json:"wallet"`
+	Password string `json:"password"`
+}
+
+// EnterWalletPasswordResponse holds the response of password_enter.
+type EnterWalletPasswordResponse struct {
+	Valid Flag `json:"valid"`
+}
+
+// Enters the password in to wallet.
+func (c *Client) EnterWalletPassword(ctx context.Context, wallet, password string) (bool, error) {
+	payload := map[string]interface{}{
+		"wallet":   wallet,
+		"password": password,
+	}
+
+	var r EnterWalletPasswordResponse
+	if err := c.fetch(ctx, "password_enter", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Valid), nil
+}
+
+// WalletPasswordValidRequest holds the params of password_valid, for use with Call.
+type WalletPasswordValidRequest struct {
+	Wallet   string `json:"wallet"`
+	Password string `json:"password"`
+}
+
+// WalletPasswordValidResponse holds the response of password_valid.
+type WalletPasswordValidResponse struct {
+	Valid Flag `json:"valid"`
+}
+
+// Checks whether the password entered for wallet is valid.
+func (c *Client) WalletPasswordValid(ctx context.Context, wallet, password string) (bool, error) {
+	payload := map[string]interface{}{
+		"wallet":   wallet,
+		"password": password,
+	}
+
+	var r WalletPasswordValidResponse
+	if err := c.fetch(ctx, "password_valid", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Valid), nil
+}
+
+// IsWalletLockedRequest holds the params of password_locked, for use with Call.
+type IsWalletLockedRequest struct {
+	Wallet string `json:"wallet"`
+}
+
+// IsWalletLockedResponse holds the response of password_locked.
+type IsWalletLockedResponse struct {
+	Locked Flag `json:"locked"`
+}
+
+// Checks whether wallet is locked.
+func (c *Client) IsWalletLocked(ctx context.Context, wallet string) (bool, error) {
+	payload := map[string]interface{}{
+		"wallet": wallet,
+	}
+
+	var r IsWalletLockedResponse
+	if err := c.fetch(ctx, "password_locked", payload, &r); err != nil {
+		return false, err
+	}
+
+	return bool(r.Locked), nil
+}
+
+// DeterministicKeyRequest holds the params of deterministic_key, for use with Call.
+type DeterministicKeyRequest struct {
+	Seed  string `json:"seed"`
+	Index int    `json:"index"`
+}
+
+// DeterministicKeyResponse holds the response of deterministic_key.
+type DeterministicKeyResponse struct {
+	Private string `json:"private"`
+	Public  string `json:"public"`
+	Account string `json:"account"`
+}
+
+// Derive deterministic keypair from seed based on index.
+func (c *Client) DeterministicKey(ctx context.Context, seed string, index int) (map[string]string, error) {
+	payload := map[string]interface{}{
+		"seed":  seed,
+		"index": index,
+	}
+
+	return c.fetchMap(ctx, "deterministic_key", payload, "")
+}
+
+// KeyCreateRequest holds the params of key_create, for use with Call.
+type KeyCreateRequest struct {
+}
+
+// KeyCreateResponse holds the response of key_create.
+type KeyCreateResponse struct {
+	Private string `json:"private"`
+	Public  string `json:"public"`
+	Account string `json:"account"`
+}
+
+// Generates an adhoc random keypair.
+func (c *Client) KeyCreate(ctx context.Context) (map[string]string, error) {
+	return c.fetchMap(ctx, "key_create", nil, "")
+}
+
+// KeyExpandRequest holds the params of key_expand, for use with Call.
+type KeyExpandRequest struct {
+	Key string `json:"key"`
+}
+
+// KeyExpandResponse holds the response of key_expand.
+type KeyExpandResponse struct {
+	Private string `json:"private"`
+	Public  string `json:"public"`
+	Account string `json:"account"`
+}
+
+// Derives public key and account number from private key.
+func (c *Client) KeyExpand(ctx context.Context, key string) (map[string]string, error) {
+	payload := map[string]interface{}{
+		"key": key,
+	}
+
+	return c.fetchMap(ctx, "key_expand", payload, "")
+}
@@ -0,0 +1,134 @@
+package rpc
+
+import (
+	"context"
+)
+
+// LedgerReader reads accounts, blocks and the ledger.
+type LedgerReader interface {
+	GetAccount(ctx context.Context, key string) (string, error)
+	AccountInfo(ctx context.Context, account string, representative, weight, pending, includeConfirmed bool) (*Account, error)
+	AccountsInfo(ctx context.Context, accounts []string, representative, weight, pending, includeConfirmed bool) (map[string]*Account, error)
+	AccountBalance(ctx context.Context, account string) (string, string, error)
+	AccountBlockCount(ctx context.Context, account string) (int, error)
+	AccountHistory(ctx context.Context, account string, count int) ([]map[string]string, error)
+	AccountKey(ctx context.Context, account string) (string, error)
+	AccountRepresentative(ctx context.Context, account string) (string, error)
+	AccountWeight(ctx context.Context, account string) (string, error)
+	AccountsBalances(ctx context.Context, accounts []string) (map[string]map[string]string, error)
+	AccountsFrontiers(ctx context.Context, accounts []string) (map[string]string, error)
+	AccountsPending(ctx context.Context, accounts []string, count int, threshold, source string) (map[string]interface{}, error)
+	Delegators(ctx context.Context, account string) (map[string]string, error)
+	DelegatorsCount(ctx context.Context, account string) (int, error)
+	Frontiers(ctx context.Context, account string, count int) (map[string]string, error)
+	ValidateAccountNumber(ctx context.Context, account string) (bool, error)
+	Pending(ctx context.Context, account string, count, threshold int, source bool) (interface{}, error)
+	PendingExists(ctx context.Context, hash string) (bool, error)
+	GetBlock(ctx context.Context, hash string) (Block, error)
+	Blocks(ctx context.Context, hashes []string) (map[string]Block, error)
+	BlocksInfo(ctx context.Context, hashes []string, pending, source bool) (map[string]*BlockInfo, error)
+	BlockAccount(ctx context.Context, hash string) (string, error)
+	BlockCount(ctx context.Context) (map[string]string, error)
+	BlockCountType(ctx context.Context) (map[string]string, error)
+	Successors(ctx context.Context, block string, count int) ([]string, error)
+	Chain(ctx context.Context, block string, count int) ([]string, error)
+	History(ctx context.Context, hash string, count int) ([]map[string]string, error)
+	AvailableSupply(ctx context.Context) (string, error)
+	FrontierCount(ctx context.Context) (int, error)
+	Representatives(ctx context.Context, count int, sort bool) (map[string]string, error)
+	Ledger(ctx context.Context, account string, count int, representative, weight, pending, sorting bool) (map[string]*Account, error)
+	UncheckedBlocks(ctx context.Context, count int) (map[string]Block, error)
+	GetUncheckedBlock(ctx context.Context, hash string) (Block, error)
+	UncheckedKeys(ctx context.Context, key string, count int) ([]UncheckedEntry, error)
+}
+
+// WalletController manages wallets, their accounts and their funds.
+// Most of it requires enable_control.
+type WalletController interface {
+	CreateWallet(ctx context.Context) (string, error)
+	DestroyWallet(ctx context.Context, wallet string) error
+	ExportWallet(ctx context.Context, wallet string) (string, error)
+	ChangeWalletSeed(ctx context.Context, wallet, seed string) (bool, error)
+	WalletAdd(ctx context.Context, wallet, key string, work bool) (string, error)
+	WalletContains(ctx context.Context, wallet, account string) (bool, error)
+	CreateAccount(ctx context.Context, wallet string, work bool) (string, error)
+	CreateAccounts(ctx context.Context, wallet string, count int, work bool) ([]string, error)
+	AccountList(ctx context.Context, wallet string) ([]string, error)
+	MoveAccounts(ctx context.Context, wallet, source string, accounts []string) (bool, error)
+	RemoveAccount(ctx context.Context, wallet, account string) (bool, error)
+	SetAccountRepresentative(ctx context.Context, wallet, account, representative, work string) (string, error)
+	WalletRepresentative(ctx context.Context, wallet string) (string, error)
+	SetWalletRepresentative(ctx context.Context, wallet, representative string) (bool, error)
+	WalletTotalBalance(ctx context.Context, wallet string) (map[string]string, error)
+	WalletBalances(ctx context.Context, wallet string, threshold int) (map[string]map[string]string, error)
+	WalletFrontiers(ctx context.Context, wallet string) (map[string]string, error)
+	WalletPending(ctx context.Context, wallet string, count, threshold int, source bool) (map[string]interface{}, error)
+	WalletRepublish(ctx context.Context, wallet string, count int) ([]string, error)
+	WalletWorkGet(ctx context.Context, wallet string) (map[string]string, error)
+	GetWork(ctx context.Context, wallet, account string) (string, error)
+	SetWork(ctx context.Context, wallet, account, work string) (bool, error)
+	ChangeWalletPassword(ctx context.Context, wallet, password string) (bool, error)
+	EnterWalletPassword(ctx context.Context, wallet, password string) (bool, error)
+	WalletPasswordValid(ctx context.Context, wallet, password string) (bool, error)
+	IsWalletLocked(ctx context.Context, wallet string) (bool, error)
+	Send(ctx context.Context, wallet, source, destination, id string, amount int, work string) (string, error)
+	ReceiveBlock(ctx context.Context, wallet, account, block, work string) (string, error)
+	SearchPending(ctx context.Context, wallet string) (bool, error)
+	SearchAllPending(ctx context.Context) (bool, error)
+	GetReceiveMinimum(ctx context.Context) (string, error)
+	SetReceiveMinimum(ctx context.Context, amount string) (bool, error)
+	BeginPayment(ctx context.Context, wallet string) (string, error)
+	InitPayment(ctx context.Context, wallet string) (string, error)
+	EndPayment(ctx context.Context, wallet, account string) error
+	WaitPayment(ctx context.Context, account, amount string, timeout int) (string, error)
+}
+
+// BlockPublisher creates blocks and publishes them to the network.
+type BlockPublisher interface {
+	CreateOpenBlock(ctx context.Context, key, account, representative, source, work string) (string, *LegacyOpenBlock, error)
+	CreateReceiveBlock(ctx context.Context, wallet, account, source, previous, work string) (string, *LegacyReceiveBlock, error)
+	CreateSendBlock(ctx context.Context, wallet, account, destination, balance, amount, previous, work string) (string, *LegacySendBlock, error)
+	CreateChangeBlock(ctx context.Context, wallet, account, representative, previous, work string) (string, *LegacyChangeBlock, error)
+	CreateStateBlock(ctx context.Context, subtype string, req CreateStateBlockRequest) (string, *StateBlock, error)
+	ProcessBlock(ctx context.Context, block Block, subtype string, force, async bool) (string, error)
+	Republish(ctx context.Context, hash string, count, sources, destinations int) ([]string, error)
+}
+
+// WorkProvider generates and validates proof of work.
+type WorkProvider interface {
+	GenerateWork(ctx context.Context, hash string) (string, error)
+	CancelWork(ctx context.Context, hash string) error
+	ValidateWork(ctx context.Context, work, hash string) (bool, error)
+	AddWorkPeer(ctx context.Context, address, port string) (bool, error)
+	GetWorkPeers(ctx context.Context) ([]string, error)
+	ClearWorkPeers(ctx context.Context) (bool, error)
+}
+
+// NodeController queries and administers the node itself.
+type NodeController interface {
+	Version(ctx context.Context) (map[string]string, error)
+	Capabilities(ctx context.Context) (Capabilities, error)
+	Peers(ctx context.Context) (map[string]string, error)
+	SendKeepalive(ctx context.Context, address string, port int) error
+	Bootstrap(ctx context.Context, address string, port int) (bool, error)
+	BootstrapAny(ctx context.Context) (bool, error)
+	ClearUncheckedBlocks(ctx context.Context) (bool, error)
+	DeterministicKey(ctx context.Context, seed string, index int) (map[string]string, error)
+	KeyCreate(ctx context.Context) (map[string]string, error)
+	KeyExpand(ctx context.Context, key string) (map[string]string, error)
+	Stop(ctx context.Context) (bool, error)
+}
+
+// API covers every action of the node. *Client implements it;
+// package rpcmock has a mock implementation for tests.
+type API interface {
+	LedgerReader
+	WalletController
+	BlockPublisher
+	WorkProvider
+	NodeController
+
+	Call(ctx context.Context, action string, params interface{}, out interface{}) error
+}
+
+var _ API = (*Client)(nil)
@@ -1,267 +1,594 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 )
 
-type Block struct {
-	Hash           string
-	Work           string
-	Previous       string
-	Source         string
-	Root           string
-	Representative string
+// Subtypes of state blocks.
+const (
+	SubtypeSend    = "send"
+	SubtypeReceive = "receive"
+	SubtypeOpen    = "open"
+	SubtypeChange  = "change"
+	SubtypeEpoch   = "epoch"
+)
+
+// Previous of open blocks and link of change blocks.
+const zeroHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// Alphabet of the base32 encoding of account numbers.
+const accountAlphabet = "13456789abcdefghijkmnopqrstuwxyz"
+
+// Block is a block of any kind: a *StateBlock, or a *LegacySendBlock,
+// *LegacyReceiveBlock, *LegacyOpenBlock or *LegacyChangeBlock.
+type Block interface {
+	// Returns the type of the block, e.g. "state".
+	Type() string
+	// Returns the hash of the block, as reported by the node it was read
+	// from or published to. Empty for blocks that were not published yet.
+	Hash() string
+	// Returns the hash of the previous block of the account,
+	// all zeros for the first block of a state chain.
+	Previous() string
+	// Returns the root of the block, which its work is computed for: the
+	// previous block, or the public key of the account for the first block.
+	Root() string
+
+	setHash(hash string)
 }
 
-// Retrieves a json representation of block.
-func GetBlock(hash string) (map[string]string, error) {
-	payload := map[string]interface{}{
-		"hash": hash,
-	}
+// Hash of a block, shared by all kinds.
+type hashed struct {
+	hash string
+}
 
-	return client.fetchMap("block", payload, "contents")
+func (h hashed) Hash() string {
+	return h.hash
 }
 
-// Retrieves a json representations of blocks.
-func Blocks(hashes []string) (map[string]map[string]string, error) {
-	payload := map[string]interface{}{
-		"hashes": hashes,
+func (h *hashed) setHash(hash string) {
+	h.hash = hash
+}
+
+// StateBlock is the block of current networks, where every block carries
+// the account, representative and balance after it. Its subtype follows
+// from the change in balance and from link, which is the destination
+// account of a send and the hash of the received send otherwise.
+type StateBlock struct {
+	hashed
+	Account        string `json:"account"`
+	PreviousHash   string `json:"previous"`
+	Representative string `json:"representative"`
+	// Balance of the account after the block, in raw.
+	Balance       string `json:"balance"`
+	Link          string `json:"link"`
+	LinkAsAccount string `json:"link_as_account,omitempty"`
+	Signature     string `json:"signature"`
+	Work          string `json:"work"`
+}
+
+func (b *StateBlock) Type() string {
+	return "state"
+}
+
+func (b *StateBlock) Previous() string {
+	return b.PreviousHash
+}
+
+func (b *StateBlock) Root() string {
+	if b.PreviousHash == "" || b.PreviousHash == zeroHash {
+		return accountKey(b.Account)
 	}
 
-	raw, err := client.call("blocks", payload)
-	if err != nil {
+	return b.PreviousHash
+}
+
+func (b StateBlock) MarshalJSON() ([]byte, error) {
+	type plain StateBlock
+	return marshalBlock("state", plain(b))
+}
+
+func (b *StateBlock) UnmarshalJSON(data []byte) error {
+	type plain StateBlock
+	return unmarshalBlock(data, b, (*plain)(b))
+}
+
+// LegacySendBlock is a send of the kind superseded by state blocks.
+type LegacySendBlock struct {
+	hashed
+	PreviousHash string `json:"previous"`
+	Destination  string `json:"destination"`
+	// Balance of the account after the send, in raw, hex encoded.
+	Balance   string `json:"balance"`
+	Signature string `json:"signature"`
+	Work      string `json:"work"`
+}
+
+func (b *LegacySendBlock) Type() string {
+	return "send"
+}
+
+func (b *LegacySendBlock) Previous() string {
+	return b.PreviousHash
+}
+
+func (b *LegacySendBlock) Root() string {
+	return b.PreviousHash
+}
+
+func (b LegacySendBlock) MarshalJSON() ([]byte, error) {
+	type plain LegacySendBlock
+	return marshalBlock("send", plain(b))
+}
+
+func (b *LegacySendBlock) UnmarshalJSON(data []byte) error {
+	type plain LegacySendBlock
+	return unmarshalBlock(data, b, (*plain)(b))
+}
+
+// LegacyReceiveBlock is a receive of the kind superseded by state blocks.
+type LegacyReceiveBlock struct {
+	hashed
+	PreviousHash string `json:"previous"`
+	Source       string `json:"source"`
+	Signature    string `json:"signature"`
+	Work         string `json:"work"`
+}
+
+func (b *LegacyReceiveBlock) Type() string {
+	return "receive"
+}
+
+func (b *LegacyReceiveBlock) Previous() string {
+	return b.PreviousHash
+}
+
+func (b *LegacyReceiveBlock) Root() string {
+	return b.PreviousHash
+}
+
+func (b LegacyReceiveBlock) MarshalJSON() ([]byte, error) {
+	type plain LegacyReceiveBlock
+	return marshalBlock("receive", plain(b))
+}
+
+func (b *LegacyReceiveBlock) UnmarshalJSON(data []byte) error {
+	type plain LegacyReceiveBlock
+	return unmarshalBlock(data, b, (*plain)(b))
+}
+
+// LegacyOpenBlock is the first block of an account, of the kind
+// superseded by state blocks.
+type LegacyOpenBlock struct {
+	hashed
+	Source         string `json:"source"`
+	Representative string `json:"representative"`
+	Account        string `json:"account"`
+	Signature      string `json:"signature"`
+	Work           string `json:"work"`
+}
+
+func (b *LegacyOpenBlock) Type() string {
+	return "open"
+}
+
+// Returns an empty hash, open blocks have no previous block.
+func (b *LegacyOpenBlock) Previous() string {
+	return ""
+}
+
+func (b *LegacyOpenBlock) Root() string {
+	return accountKey(b.Account)
+}
+
+func (b LegacyOpenBlock) MarshalJSON() ([]byte, error) {
+	type plain LegacyOpenBlock
+	return marshalBlock("open", plain(b))
+}
+
+func (b *LegacyOpenBlock) UnmarshalJSON(data []byte) error {
+	type plain LegacyOpenBlock
+	return unmarshalBlock(data, b, (*plain)(b))
+}
+
+// LegacyChangeBlock is a change of representative, of the kind
+// superseded by state blocks.
+type LegacyChangeBlock struct {
+	hashed
+	PreviousHash   string `json:"previous"`
+	Representative string `json:"representative"`
+	Signature      string `json:"signature"`
+	Work           string `json:"work"`
+}
+
+func (b *LegacyChangeBlock) Type() string {
+	return "change"
+}
+
+func (b *LegacyChangeBlock) Previous() string {
+	return b.PreviousHash
+}
+
+func (b *LegacyChangeBlock) Root() string {
+	return b.PreviousHash
+}
+
+func (b LegacyChangeBlock) MarshalJSON() ([]byte, error) {
+	type plain LegacyChangeBlock
+	return marshalBlock("change", plain(b))
+}
+
+func (b *LegacyChangeBlock) UnmarshalJSON(data []byte) error {
+	type plain LegacyChangeBlock
+	return unmarshalBlock(data, b, (*plain)(b))
+}
+
+// Decodes a block of any kind, from either the stringified json nodes
+// send by default or the object they send when json_block is set.
+func DecodeBlock(data []byte) (Block, error) {
+	data = unquoteBlock(data)
+
+	var t struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
 		return nil, err
 	}
 
-	var r map[string]map[string]map[string]string
-	if err = json.Unmarshal(raw, &r); err != nil {
-		return nil, err
+	var b Block
+	switch t.Type {
+	case "state":
+		b = new(StateBlock)
+	case "send":
+		b = new(LegacySendBlock)
+	case "receive":
+		b = new(LegacyReceiveBlock)
+	case "open":
+		b = new(LegacyOpenBlock)
+	case "change":
+		b = new(LegacyChangeBlock)
+	default:
+		return nil, &json.UnmarshalTypeError{Value: "block of type " + strconv.Quote(t.Type), Type: reflect.TypeOf((*Block)(nil)).Elem()}
 	}
 
-	blocks, ok := r["blocks"]
-	if !ok {
-		return nil, errors.New("Response of blocks is empty")
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, err
 	}
 
-	return blocks, nil
+	return b, nil
 }
 
-// Retrieves a json representations of blocks with transaction
-// amount & block account.
-// Additionally checks if block is pending, returns source account
-// for receive & open blocks (0 for send & change blocks) (>= v8.1).
-func BlocksInfo(hashes []string, pending, source bool) (map[string]interface{}, error) {
-	payload := map[string]interface{}{
-		"hashes":  hashes,
-		"pending": pending,
-		"source":  source,
-	}
+// BlockMap maps hashes to blocks of any kind, which get
+// their hash from the map. Used to decode responses.
+type BlockMap map[string]Block
 
-	return client.fetchMapInterface("blocks_info", payload, "blocks")
-}
+func (m *BlockMap) UnmarshalJSON(data []byte) error {
+	var blocks Map[anyBlock]
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		return err
+	}
 
-// Returns the account containing block.
-func BlockAccount(hash string) (string, error) {
-	payload := map[string]interface{}{
-		"hash": hash,
+	*m = make(BlockMap, len(blocks))
+	for hash, b := range blocks {
+		b.setHash(strings.ToUpper(hash))
+		(*m)[hash] = b.Block
 	}
 
-	return client.fetchString("block_account", payload, "account")
+	return nil
 }
 
-// Reports the number of blocks in the ledger
-// and unchecked synchronizing blocks.
-func BlockCount() (map[string]string, error) {
-	return client.fetchMap("block_count", nil, "")
+// BlockInfo is a block along with what the ledger knows about it.
+type BlockInfo struct {
+	Account string
+	Amount  *big.Int
+	// Balance of the account after the block, height of the block
+	// in its chain and when the node saw it first (>= v19.0).
+	Balance        *big.Int
+	Height         uint64
+	LocalTimestamp time.Time
+	// Hash of the next block of the chain, all zeros for frontiers (>= v19.0).
+	Successor string
+	// Whether the block is confirmed (>= v19.0).
+	Confirmed bool
+	// Subtype of state blocks (>= v19.0).
+	Subtype string
+	// Set when asked for, whether the block is still receivable.
+	Pending bool
+	// Set when asked for, the account sending the block received
+	// by receives and opens, "0" for other blocks.
+	SourceAccount string
+	Contents      Block
 }
 
-// Reports the number of blocks in the ledger
-// by type (send, receive, open, change).
-func BlockCountType() (map[string]string, error) {
-	return client.fetchMap("block_count_type", nil, "")
-}
+func (b *BlockInfo) UnmarshalJSON(data []byte) error {
+	var r struct {
+		BlockAccount   string   `json:"block_account"`
+		Amount         string   `json:"amount"`
+		Balance        string   `json:"balance"`
+		Height         string   `json:"height"`
+		LocalTimestamp string   `json:"local_timestamp"`
+		Successor      string   `json:"successor"`
+		Confirmed      string   `json:"confirmed"`
+		Subtype        string   `json:"subtype"`
+		Pending        string   `json:"pending"`
+		Receivable     string   `json:"receivable"`
+		SourceAccount  string   `json:"source_account"`
+		Contents       anyBlock `json:"contents"`
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
 
-// Creates a json representations of a new open block
-// based on input data & signed with private key (>= v8.1).
-// Optionally uses work value for block from external source.
-// Requires enable_control
-func CreateOpenBlock(key, account, representative, source, work string) (map[string]string, error) {
-	payload := map[string]interface{}{
-		"type":           "open",
-		"key":            key,
-		"account":        account,
-		"representative": representative,
-		"source":         source,
+	*b = BlockInfo{
+		Account:       r.BlockAccount,
+		Successor:     r.Successor,
+		Confirmed:     truthy(r.Confirmed),
+		Subtype:       r.Subtype,
+		Pending:       truthy(r.Pending) || truthy(r.Receivable),
+		SourceAccount: r.SourceAccount,
+		Contents:      r.Contents.Block,
+	}
+
+	var timestamp uint64
+	p := accountParser{}
+	p.raw(&b.Amount, "amount", r.Amount)
+	p.raw(&b.Balance, "balance", r.Balance)
+	p.uint(&b.Height, "height", r.Height)
+	p.uint(&timestamp, "local_timestamp", r.LocalTimestamp)
+	if p.err != nil {
+		return p.err
 	}
 
-	if work != "" {
-		payload["work"] = work
+	if r.LocalTimestamp != "" {
+		b.LocalTimestamp = time.Unix(int64(timestamp), 0)
 	}
 
-	return client.fetchMap("block_create", payload, "")
+	return nil
 }
 
-// Creates a json representations of a new receive block (>= v8.1).
-// Optionally uses work value for block from external source.
-// Requires enable_control
-func CreateReceiveBlock(wallet, account, source, previous, work string) (map[string]string, error) {
-	payload := map[string]interface{}{
-		"type":     "receive",
-		"wallet":   wallet,
-		"account":  account,
-		"source":   source,
-		"previous": previous,
-	}
+// Decodes a block of any kind.
+type anyBlock struct {
+	Block
+}
+
+func (b *anyBlock) UnmarshalJSON(data []byte) (err error) {
+	b.Block, err = DecodeBlock(data)
+
+	return err
+}
 
-	if work != "" {
-		payload["work"] = work
+// Encodes the fields of a block, which must not have
+// a MarshalJSON method, after its type.
+func marshalBlock(typ string, fields interface{}) ([]byte, error) {
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
 	}
 
-	return client.fetchMap("block_create", payload, "")
+	return append([]byte(`{"type":"`+typ+`",`), raw[1:]...), nil
 }
 
-// Creates a json representations of a new send block (>= v8.1).
-// Optionally uses work value for block from external source.
-// Requires enable_control
-func CreateSendBlock(wallet, account, destination, balance, amount, previous, work string) (map[string]string, error) {
-	payload := map[string]interface{}{
-		"type":     "send",
-		"wallet":   wallet,
-		"account":  account,
-		"balance":  balance,
-		"amount":   amount,
-		"previous": previous,
+// Decodes data into fields, the fields of b without
+// its UnmarshalJSON method.
+func unmarshalBlock(data []byte, b Block, fields interface{}) error {
+	data = unquoteBlock(data)
+
+	var t struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
 	}
 
-	if work != "" {
-		payload["work"] = work
+	if t.Type != "" && t.Type != b.Type() {
+		return &json.UnmarshalTypeError{Value: "block of type " + strconv.Quote(t.Type), Type: reflect.TypeOf(b)}
 	}
 
-	return client.fetchMap("block_create", payload, "")
+	return json.Unmarshal(data, fields)
 }
 
-// Creates a json representations of a new change block (>= v8.1).
-// Optionally uses work value for block from external source.
-// Requires enable_control
-func CreateChangeBlock(wallet, account, representative, previous, work string) (map[string]string, error) {
-	payload := map[string]interface{}{
-		"type":           "change",
-		"wallet":         wallet,
-		"account":        account,
-		"representative": representative,
-		"previous":       previous,
+// Returns the json of a block given as stringified json.
+func unquoteBlock(data []byte) []byte {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		return []byte(s)
 	}
 
-	if work != "" {
-		payload["work"] = work
+	return data
+}
+
+// Returns the public key of account, hex encoded in upper case, or an
+// empty string if account is malformed. The checksum is not verified.
+func accountKey(account string) string {
+	rest := stripPrefix(account)
+	if len(rest) != 60 {
+		return ""
 	}
 
-	return client.fetchMap("block_create", payload, "")
-}
+	// 4 bits of padding followed by the 256 bit key make 52 characters.
+	key := new(big.Int)
+	for i := 0; i < 52; i++ {
+		v := strings.IndexByte(accountAlphabet, rest[i])
+		if v < 0 {
+			return ""
+		}
+		key.Lsh(key, 5).Or(key, big.NewInt(int64(v)))
+	}
 
-// Publishes block to the network.
-func ProcessBlock(block map[string]string) (string, error) {
-	payload := map[string]interface{}{
-		"block": block,
+	if key.BitLen() > 256 {
+		return ""
 	}
 
-	return client.fetchString("process", payload, "hash")
+	return fmt.Sprintf("%064X", key)
 }
 
-// Checks whether block is pending by hash (>= v8.0).
-func PendingExists(hash string) (bool, error) {
+// Retrieves block by hash.
+func (c *Client) GetBlock(ctx context.Context, hash string) (Block, error) {
 	payload := map[string]interface{}{
 		"hash": hash,
 	}
 
-	return client.isSuccess("pending_exists", payload, "exists")
+	var r GetBlockResponse
+	if err := c.fetch(ctx, "block", payload, &r, "contents"); err != nil {
+		return nil, err
+	}
+
+	block, err := DecodeBlock(r.Contents)
+	if err != nil {
+		return nil, malformed("block", err)
+	}
+	block.setHash(strings.ToUpper(hash))
+
+	return block, nil
 }
 
-// Retrieves a json representation of unchecked synchronizing block by hash (>= v8.0).
-func GetUncheckedBlock(hash string) (string, error) {
+// Retrieves blocks by hash, with the account, amount and, on newer
+// nodes, the height, balance and confirmation of each. Additionally
+// checks if blocks are pending and returns the source account of
+// receive and open blocks, if respective parameters are set (>= v8.1).
+func (c *Client) BlocksInfo(ctx context.Context, hashes []string, pending, source bool) (map[string]*BlockInfo, error) {
 	payload := map[string]interface{}{
-		"hash": hash,
+		"hashes":  hashes,
+		"pending": pending,
+		"source":  source,
+	}
+
+	var r BlocksInfoResponse
+	if err := c.fetch(ctx, "blocks_info", payload, &r, "blocks"); err != nil {
+		return nil, err
+	}
+
+	for hash, info := range r.Blocks {
+		if info == nil || info.Contents == nil {
+			return nil, fmt.Errorf("%w: response of blocks_info has no contents for %s", ErrMalformedResponse, hash)
+		}
+		info.Contents.setHash(strings.ToUpper(hash))
 	}
 
-	return client.fetchString("unchecked_get", payload, "contents")
+	return map[string]*BlockInfo(r.Blocks), nil
 }
 
-// Stops generating work for block.
-// Requires enable_control.
-func CancelWork(hash string) error {
+// Retrieves an unchecked synchronizing block by hash (>= v8.0).
+func (c *Client) GetUncheckedBlock(ctx context.Context, hash string) (Block, error) {
 	payload := map[string]interface{}{
 		"hash": hash,
 	}
 
-	_, err := client.call("work_cancel", payload)
+	var r GetUncheckedBlockResponse
+	if err := c.fetch(ctx, "unchecked_get", payload, &r, "contents"); err != nil {
+		return nil, err
+	}
 
-	return err
+	block, err := DecodeBlock(r.Contents)
+	if err != nil {
+		return nil, malformed("unchecked_get", err)
+	}
+	block.setHash(strings.ToUpper(hash))
+
+	return block, nil
 }
 
-// Generates work for block.
+// Creates a state block of subtype, one of the Subtype constants,
+// signed with req.Key or the key of req.Account in req.Wallet (>= v11.0).
+// The link of req is the destination account for sends, the hash of the
+// send to receive for receives and opens, and the epoch signer for epoch
+// blocks. It takes an account or the hex public key of one, which the
+// node encodes the same. Change blocks and opens may leave link and
+// previous empty.
+// If req.JSONBlock is set, the node returns the block as an object (>= v19.0).
+// Returns the hash of the block and the block, ready for ProcessBlock.
 // Requires enable_control.
-func GenerateWork(hash string) (string, error) {
-	payload := map[string]interface{}{
-		"hash": hash,
+func (c *Client) CreateStateBlock(ctx context.Context, subtype string, req CreateStateBlockRequest) (string, *StateBlock, error) {
+	switch subtype {
+	case SubtypeOpen:
+		if req.Previous == "" {
+			req.Previous = zeroHash
+		}
+	case SubtypeChange:
+		if req.Link == "" {
+			req.Link = zeroHash
+		}
+	case SubtypeSend, SubtypeReceive, SubtypeEpoch:
+	default:
+		return "", nil, fmt.Errorf("rpc: block_create: unknown subtype %q", subtype)
 	}
 
-	return client.fetchString("work_generate", payload, "work")
-}
+	if req.Previous == "" {
+		return "", nil, fmt.Errorf("rpc: block_create: %s blocks need a previous block", subtype)
+	}
 
-// Checks whether work is valid for block.
-func ValidateWork(work, hash string) (bool, error) {
-	payload := map[string]interface{}{
-		"work": work,
-		"hash": hash,
+	if req.Link == "" {
+		return "", nil, fmt.Errorf("rpc: block_create: %s blocks need a link", subtype)
 	}
 
-	return client.isSuccess("work_validate", payload, "valid")
-}
+	req.Type = "state"
+	payload, err := toPayload("block_create", req)
+	if err != nil {
+		return "", nil, err
+	}
 
-// Returns a list of block hashes in the account
-// chain ending at block up to count.
-func Successors(block string, count int) ([]string, error) {
-	payload := map[string]interface{}{
-		"block": block,
-		"count": count,
+	var r CreateStateBlockResponse
+	if err = c.fetch(ctx, "block_create", payload, &r, "hash", "block"); err != nil {
+		return "", nil, err
+	}
+
+	if r.Block == nil {
+		return "", nil, fmt.Errorf("%w: response of block_create has a null block", ErrMalformedResponse)
 	}
+	r.Block.setHash(r.Hash)
 
-	return client.fetchSlice("successors", payload, "blocks")
+	return r.Hash, r.Block, nil
 }
 
-// Returns a list of block hashes in the account
-// chain starting at block up to count.
-func Chain(block string, count int) ([]string, error) {
-	payload := map[string]interface{}{
-		"block": block,
-		"count": count,
+// Publishes block to the network and sets its hash.
+// If subtype is not empty, the node rejects the block unless
+// it is of that subtype (>= v18.0).
+// If force is set, the block replaces a fork of it in the ledger.
+// If async is set, returns as soon as the node queued the block,
+// with an empty hash (>= v22.0).
+func (c *Client) ProcessBlock(ctx context.Context, block Block, subtype string, force, async bool) (string, error) {
+	contents, err := json.Marshal(block)
+	if err != nil {
+		return "", err
 	}
 
-	return client.fetchSlice("chain", payload, "blocks")
-}
+	// A nil block or nil pointer to one.
+	if string(contents) == "null" {
+		return "", fmt.Errorf("rpc: process: no block given")
+	}
 
-// Reports send/receive information for a chain of blocks.
-func History(hash string, count int) ([]map[string]string, error) {
 	payload := map[string]interface{}{
-		"hash":  hash,
-		"count": count,
+		"block": string(contents),
 	}
 
-	raw, err := client.call("history", payload)
-	if err != nil {
-		return nil, err
+	if subtype != "" {
+		payload["subtype"] = subtype
 	}
 
-	var r map[string][]map[string]string
-	if err = json.Unmarshal(raw, &r); err != nil {
-		return nil, err
+	if force {
+		payload["force"] = true
+	}
+
+	if async {
+		payload["async"] = true
+
+		_, err = c.call(ctx, "process", payload)
+
+		return "", err
 	}
 
-	history, ok := r["history"]
-	if !ok {
-		return nil, errors.New("Response of history is empty")
+	var r ProcessBlockResponse
+	if err = c.fetch(ctx, "process", payload, &r, "hash"); err != nil {
+		return "", err
 	}
+	block.setHash(r.Hash)
 
-	return history, nil
+	return r.Hash, nil
 }
@@ -0,0 +1,405 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+func TestCreateAndProcessStateBlock(t *testing.T) {
+	for _, jsonBlock := range []bool{false, true} {
+		n := rpctest.NewNode()
+		defer n.Close()
+		c := n.Client()
+		ctx := context.Background()
+
+		wallet, account := fundedWallet(t, n, c, "1000")
+		info, err := c.AccountInfo(ctx, account, true, false, false, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// A send, linking to the destination account.
+		hash, block, err := c.CreateStateBlock(ctx, rpc.SubtypeSend, rpc.CreateStateBlockRequest{
+			Wallet:         wallet,
+			Account:        account,
+			Previous:       info.Frontier,
+			Representative: info.Representative,
+			Balance:        "600",
+			Link:           n.Genesis(),
+			JSONBlock:      jsonBlock,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if block.Hash() != hash {
+			t.Errorf("json_block %v: created block has hash %q, want %s", jsonBlock, block.Hash(), hash)
+		}
+		if key, err := c.AccountKey(ctx, n.Genesis()); err != nil || block.Link != key {
+			t.Errorf("json_block %v: link %s, want the key %s of the destination (%v)", jsonBlock, block.Link, key, err)
+		}
+
+		processed, err := c.ProcessBlock(ctx, block, rpc.SubtypeSend, false, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if processed != hash || block.Hash() != hash {
+			t.Errorf("json_block %v: processed block has hash %s, want %s", jsonBlock, processed, hash)
+		}
+
+		if balance, _, err := c.AccountBalance(ctx, account); err != nil || balance != "600" {
+			t.Errorf("json_block %v: balance after the send = %s, %v, want 600", jsonBlock, balance, err)
+		}
+	}
+}
+
+// Blocks of every create method carry the hash the node returned.
+func TestCreateLegacyBlocks(t *testing.T) {
+	const hash = "991CF190094C00F0B68E2E5F75F6BEE95A2E0BD93CEAA4A6734DB9F19B728948"
+
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+
+	tests := []struct {
+		typ    string
+		create func() (string, rpc.Block, error)
+	}{
+		{"open", func() (string, rpc.Block, error) {
+			h, b, err := c.CreateOpenBlock(ctx, "", "", "", "", "")
+			return h, b, err
+		}},
+		{"receive", func() (string, rpc.Block, error) {
+			h, b, err := c.CreateReceiveBlock(ctx, "", "", "", "", "")
+			return h, b, err
+		}},
+		{"send", func() (string, rpc.Block, error) {
+			h, b, err := c.CreateSendBlock(ctx, "", "", "", "", "", "", "")
+			return h, b, err
+		}},
+		{"change", func() (string, rpc.Block, error) {
+			h, b, err := c.CreateChangeBlock(ctx, "", "", "", "", "")
+			return h, b, err
+		}},
+	}
+
+	for _, tt := range tests {
+		n.OnRequest(reply(`{"hash": "` + hash + `", "block": "{\"type\": \"` + tt.typ + `\"}"}`))
+		got, block, err := tt.create()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.typ, err)
+		}
+		if got != hash || block.Hash() != hash || block.Type() != tt.typ {
+			t.Errorf("%s: created %s block with hash %q, returned %s", tt.typ, block.Type(), block.Hash(), got)
+		}
+
+		n.OnRequest(reply(`{"hash": "` + hash + `", "block": null}`))
+		if _, _, err = tt.create(); !errors.Is(err, rpc.ErrMalformedResponse) {
+			t.Errorf("%s: null block returned %v, want ErrMalformedResponse", tt.typ, err)
+		}
+	}
+}
+
+func TestProcessNilBlock(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+
+	for _, block := range []rpc.Block{nil, (*rpc.StateBlock)(nil)} {
+		if _, err := c.ProcessBlock(context.Background(), block, "", false, false); err == nil {
+			t.Errorf("processing %#v succeeded", block)
+		}
+	}
+
+	if got := n.Requests("process"); got != 0 {
+		t.Errorf("sent %d process requests, want none", got)
+	}
+}
+
+// Requests missing what their subtype needs never reach the node.
+func TestCreateStateBlockSubtypes(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+
+	tests := []struct {
+		subtype string
+		req     rpc.CreateStateBlockRequest
+	}{
+		{"bogus", rpc.CreateStateBlockRequest{Previous: "0", Link: "0"}},
+		{rpc.SubtypeSend, rpc.CreateStateBlockRequest{Link: "0"}},
+		{rpc.SubtypeReceive, rpc.CreateStateBlockRequest{Previous: "0"}},
+	}
+
+	for _, tt := range tests {
+		if _, _, err := c.CreateStateBlock(ctx, tt.subtype, tt.req); err == nil {
+			t.Errorf("%s block %+v was created", tt.subtype, tt.req)
+		}
+	}
+
+	if got := n.Requests("block_create"); got != 0 {
+		t.Errorf("sent %d block_create requests, want none", got)
+	}
+}
+
+func TestBlocksInfo(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+
+	wallet, err := c.CreateWallet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := c.CreateAccount(ctx, wallet, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent, err := n.Fund(account, "1000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	open, err := c.ReceiveBlock(ctx, wallet, account, sent, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocks, err := c.BlocksInfo(ctx, []string{sent, open}, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	send, receive := blocks[sent], blocks[open]
+	if send == nil || receive == nil {
+		t.Fatalf("blocks_info returned %v", blocks)
+	}
+
+	if send.Account != n.Genesis() || send.Amount.String() != "1000" || send.Pending || send.SourceAccount != "0" {
+		t.Errorf("send info %+v", send)
+	}
+	if receive.Account != account || receive.Balance.String() != "1000" || receive.Height != 1 || receive.SourceAccount != n.Genesis() {
+		t.Errorf("open info %+v", receive)
+	}
+	if !receive.Confirmed || receive.LocalTimestamp.IsZero() {
+		t.Errorf("open is confirmed %v at %v", receive.Confirmed, receive.LocalTimestamp)
+	}
+
+	for hash, info := range blocks {
+		if info.Contents.Hash() != hash {
+			t.Errorf("contents of %s are %#v", hash, info.Contents)
+		}
+	}
+}
+
+// Blocks whose previous block is missing wait among the unchecked ones.
+func TestUncheckedBlocks(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+
+	wallet, account := fundedWallet(t, n, c, "1000")
+
+	const missing = "F0000000000000000000000000000000000000000000000000000000000000FF"
+	hash, block, err := c.CreateStateBlock(ctx, rpc.SubtypeSend, rpc.CreateStateBlockRequest{
+		Wallet:         wallet,
+		Account:        account,
+		Previous:       missing,
+		Representative: account,
+		Balance:        "1",
+		Link:           n.Genesis(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = c.ProcessBlock(ctx, block, "", false, false); err == nil {
+		t.Fatal("processed a block with a missing previous block")
+	}
+
+	unchecked, err := c.GetUncheckedBlock(ctx, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unchecked.Hash() != hash || unchecked.Previous() != missing {
+		t.Errorf("unchecked block %s follows %s, want %s following %s", unchecked.Hash(), unchecked.Previous(), hash, missing)
+	}
+
+	entries, err := c.UncheckedKeys(ctx, "0000000000000000000000000000000000000000000000000000000000000000", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d unchecked entries, want 1", len(entries))
+	}
+	if e := entries[0]; e.Key != missing || e.Hash != hash || e.Contents == nil || e.Contents.Hash() != hash {
+		t.Errorf("unchecked entry %+v", e)
+	}
+}
+
+func TestBlockJSON(t *testing.T) {
+	const (
+		hash    = "991CF190094C00F0B68E2E5F75F6BEE95A2E0BD93CEAA4A6734DB9F19B728948"
+		account = "nano_3t6k35gi95xu6tergt6p69ck76ogmitsa8mnijtpxm9fkcm736xtoncuohr3"
+		sig     = "5B11B17DB9C8FE0CC58CAC6A6EECEF9CB122DA8A81C6D3DB1B5EE3AB065AA8F8CB1D6765C8EB91B58530C5FF5987AD95E6D34BB57F44257E20795EE412E61600"
+		work    = "000bc55b014e807d"
+	)
+
+	blocks := []rpc.Block{
+		&rpc.StateBlock{Account: account, PreviousHash: hash, Representative: account, Balance: "1000", Link: hash, Signature: sig, Work: work},
+		&rpc.LegacySendBlock{PreviousHash: hash, Destination: account, Balance: "000000000000000000000000000003E8", Signature: sig, Work: work},
+		&rpc.LegacyReceiveBlock{PreviousHash: hash, Source: hash, Signature: sig, Work: work},
+		&rpc.LegacyOpenBlock{Source: hash, Representative: account, Account: account, Signature: sig, Work: work},
+		&rpc.LegacyChangeBlock{PreviousHash: hash, Representative: account, Signature: sig, Work: work},
+	}
+
+	for _, b := range blocks {
+		object, err := json.Marshal(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(object), `{"type":"`+b.Type()+`",`) {
+			t.Errorf("%s block encoded as %s", b.Type(), object)
+		}
+
+		// Nodes send blocks as stringified json unless json_block is set.
+		str, _ := json.Marshal(string(object))
+
+		for _, data := range [][]byte{object, str} {
+			decoded, err := rpc.DecodeBlock(data)
+			if err != nil {
+				t.Fatalf("decoding %s: %v", data, err)
+			}
+			if !reflect.DeepEqual(decoded, b) {
+				t.Errorf("%s decoded as %#v, want %#v", data, decoded, b)
+			}
+
+			typed := reflect.New(reflect.TypeOf(b).Elem()).Interface()
+			if err = json.Unmarshal(data, typed); err != nil || !reflect.DeepEqual(typed, b) {
+				t.Errorf("%s unmarshaled as %#v, %v", data, typed, err)
+			}
+		}
+	}
+
+	for _, data := range []string{
+		`{"type":"bogus"}`,
+		`"{\"type\":\"bogus\"}"`,
+		`[]`,
+	} {
+		if b, err := rpc.DecodeBlock([]byte(data)); err == nil {
+			t.Errorf("decoded %s as %#v", data, b)
+		}
+	}
+
+	var state rpc.StateBlock
+	if err := json.Unmarshal([]byte(`{"type":"send"}`), &state); err == nil {
+		t.Error("decoded a send block as a state block")
+	}
+}
+
+// Blocks read from the node in either form decode to the same block.
+func TestBlockJSONForms(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+
+	wallet, account := fundedWallet(t, n, c, "1000")
+
+	// Blocks waiting for a missing previous block can be read in both forms.
+	hash, created, err := c.CreateStateBlock(ctx, rpc.SubtypeSend, rpc.CreateStateBlockRequest{
+		Wallet:         wallet,
+		Account:        account,
+		Previous:       "F0000000000000000000000000000000000000000000000000000000000000FF",
+		Representative: account,
+		Balance:        "1",
+		Link:           n.Genesis(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ProcessBlock(ctx, created, "", false, false)
+
+	want, err := json.Marshal(created)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, jsonBlock := range []bool{false, true} {
+		var r struct {
+			Contents json.RawMessage `json:"contents"`
+		}
+		if err = c.Call(ctx, "unchecked_get", map[string]interface{}{"hash": hash, "json_block": jsonBlock}, &r); err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := rpc.DecodeBlock(r.Contents)
+		if err != nil {
+			t.Fatalf("json_block %v: decoding %s: %v", jsonBlock, r.Contents, err)
+		}
+		if got, _ := json.Marshal(b); string(got) != string(want) {
+			t.Errorf("json_block %v: block decoded from %s encodes as %s, want %s", jsonBlock, r.Contents, got, want)
+		}
+	}
+
+	// Funded accounts are opened with legacy blocks.
+	info, err := c.AccountInfo(ctx, account, false, false, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks, err := c.Blocks(ctx, []string{info.OpenBlock})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	open := blocks[info.OpenBlock]
+	raw, err := json.Marshal(open)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := rpc.DecodeBlock(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := json.Marshal(decoded); open.Type() != "open" || string(again) != string(raw) {
+		t.Errorf("open block %s decodes to %s", raw, again)
+	}
+}
+
+// Blocks keyed by lower case hashes still report them upper cased.
+func TestBlockMapHash(t *testing.T) {
+	const hash = "a170d51b94e00371ace76e35ac81dc9405d5d04d4cebc399aeace07ae05dd293"
+	block := `{"type": "state", "account": "nano_a", "balance": "1"}`
+
+	var m rpc.BlockMap
+	if err := json.Unmarshal([]byte(`{"`+hash+`": `+block+`}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	if b := m[hash]; b == nil || b.Hash() != strings.ToUpper(hash) {
+		t.Errorf("block map has %v for %s, want a block with its hash upper cased", b, hash)
+	}
+
+	n := rpctest.NewNode()
+	defer n.Close()
+	n.OnRequest(reply(`{"blocks": {"` + hash + `": ` + block + `}}`))
+
+	for u, err := range n.Client().StreamUncheckedBlocks(context.Background(), 1) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Contents.Hash() != strings.ToUpper(hash) {
+			t.Errorf("streamed block has hash %s, want it upper cased", u.Contents.Hash())
+		}
+	}
+}
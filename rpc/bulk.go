@@ -0,0 +1,285 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+// BulkOptions controls how bulk queries split up their accounts.
+type BulkOptions struct {
+	// Accounts per request for multi-account actions. Defaults to 1000.
+	BatchSize int
+	// Requests in flight at once. Defaults to 8.
+	Concurrency int
+}
+
+func (o BulkOptions) batchSize() int {
+	if o.BatchSize > 0 {
+		return o.BatchSize
+	}
+
+	return 1000
+}
+
+func (o BulkOptions) concurrency() int {
+	if o.Concurrency > 0 {
+		return o.Concurrency
+	}
+
+	return 8
+}
+
+// AccountResult is the outcome of a bulk query for a single account.
+type AccountResult[T any] struct {
+	Value T
+	Err   error
+}
+
+// Queries AccountInfo for every account, with bounded concurrency.
+// A failure only affects the result of its own account.
+func (c *Client) BulkAccountInfo(ctx context.Context, accounts []string, representative, weight, pending, includeConfirmed bool, opts BulkOptions) map[string]AccountResult[*Account] {
+	return fanOut(ctx, accounts, opts, func(ctx context.Context, account string) (*Account, error) {
+		return c.AccountInfo(ctx, account, representative, weight, pending, includeConfirmed)
+	})
+}
+
+// Queries AccountRepresentative for every account, with bounded concurrency.
+func (c *Client) BulkAccountRepresentative(ctx context.Context, accounts []string, opts BulkOptions) map[string]AccountResult[string] {
+	return fanOut(ctx, accounts, opts, c.AccountRepresentative)
+}
+
+// Queries AccountBlockCount for every account, with bounded concurrency.
+func (c *Client) BulkAccountBlockCount(ctx context.Context, accounts []string, opts BulkOptions) map[string]AccountResult[int] {
+	return fanOut(ctx, accounts, opts, c.AccountBlockCount)
+}
+
+// Queries AccountsBalances in batches of opts.BatchSize accounts.
+// A failed batch only affects the results of its own accounts, and a
+// batch the node rejects over a bad account is split to isolate it.
+func (c *Client) BulkAccountsBalances(ctx context.Context, accounts []string, opts BulkOptions) map[string]AccountResult[map[string]string] {
+	return batched(ctx, accounts, opts, func(account string) error {
+		return errMissingKey("accounts_balances", account)
+	}, func(ctx context.Context, batch []string) (map[string]map[string]string, map[string]error, error) {
+		return fetchBatch[map[string]string](ctx, c, "accounts_balances", "balances", map[string]interface{}{
+			"accounts": batch,
+		})
+	})
+}
+
+// Queries AccountsFrontiers in batches of opts.BatchSize accounts.
+// Unopened accounts get an error matching ErrAccountNotFound.
+func (c *Client) BulkAccountsFrontiers(ctx context.Context, accounts []string, opts BulkOptions) map[string]AccountResult[string] {
+	return batched(ctx, accounts, opts, func(string) error {
+		// Nodes before V24 leave unopened accounts out.
+		return ErrAccountNotFound
+	}, func(ctx context.Context, batch []string) (map[string]string, map[string]error, error) {
+		return fetchBatch[string](ctx, c, "accounts_frontiers", "frontiers", map[string]interface{}{
+			"accounts": batch,
+		})
+	})
+}
+
+// Queries AccountsPending in batches of opts.BatchSize accounts.
+// See AccountsPending for count, threshold and source.
+func (c *Client) BulkAccountsPending(ctx context.Context, accounts []string, count int, threshold, source string, opts BulkOptions) map[string]AccountResult[interface{}] {
+	return batched(ctx, accounts, opts, func(account string) error {
+		return errMissingKey("accounts_pending", account)
+	}, func(ctx context.Context, batch []string) (map[string]interface{}, map[string]error, error) {
+		payload := map[string]interface{}{
+			"accounts": batch,
+			"count":    count,
+		}
+
+		if threshold != "" {
+			payload["threshold"] = threshold
+		}
+
+		if source != "" {
+			payload["source"] = source
+		}
+
+		return fetchBatch[interface{}](ctx, c, "accounts_pending", "blocks", payload)
+	})
+}
+
+// Calls the multi-account action and returns the values at key, along
+// with the errors of single accounts. Since V24 nodes report those
+// under errors instead of failing the whole request.
+func fetchBatch[T any](ctx context.Context, c *Client, action, key string, payload map[string]interface{}) (map[string]T, map[string]error, error) {
+	raw, err := c.call(ctx, action, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var r map[string]json.RawMessage
+	if err = json.Unmarshal(raw, &r); err != nil {
+		return nil, nil, malformed(action, err)
+	}
+
+	var messages Map[string]
+	if v, ok := r["errors"]; ok {
+		if err = json.Unmarshal(v, &messages); err != nil {
+			return nil, nil, malformed(action, err)
+		}
+	}
+
+	var values Map[T]
+	if v, ok := r[key]; ok {
+		if err = json.Unmarshal(v, &values); err != nil {
+			return nil, nil, malformed(action, err)
+		}
+	} else if len(messages) == 0 {
+		return nil, nil, errMissingKey(action, key)
+	}
+
+	errs := make(map[string]error, len(messages))
+	for account, message := range messages {
+		errs[account] = &NodeError{Action: action, Message: message}
+	}
+
+	return values, errs, nil
+}
+
+// Returns accounts without duplicates, in order.
+func dedupe(accounts []string) []string {
+	seen := make(map[string]bool, len(accounts))
+	r := make([]string, 0, len(accounts))
+	for _, a := range accounts {
+		if !seen[a] {
+			seen[a] = true
+			r = append(r, a)
+		}
+	}
+
+	return r
+}
+
+// Runs fn for every account with at most opts.Concurrency calls in flight.
+func fanOut[T any](ctx context.Context, accounts []string, opts BulkOptions, fn func(context.Context, string) (T, error)) map[string]AccountResult[T] {
+	accounts = dedupe(accounts)
+	results := make(map[string]AccountResult[T], len(accounts))
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, opts.concurrency())
+	)
+
+	for _, account := range accounts {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			results[account] = AccountResult[T]{Err: ctx.Err()}
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(account string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			v, err := fn(ctx, account)
+
+			mu.Lock()
+			results[account] = AccountResult[T]{Value: v, Err: err}
+			mu.Unlock()
+		}(account)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// Runs fn for batches of accounts with at most opts.Concurrency calls in
+// flight and splits the merged results by account. Accounts missing from
+// a response get the error fn returned for them, or the one returned by
+// missing. Batches the node rejects over a bad or unknown account are
+// split in halves until the account is found, other errors fail the
+// whole batch.
+func batched[T any](ctx context.Context, accounts []string, opts BulkOptions, missing func(string) error, fn func(context.Context, []string) (map[string]T, map[string]error, error)) map[string]AccountResult[T] {
+	accounts = dedupe(accounts)
+	results := make(map[string]AccountResult[T], len(accounts))
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, opts.concurrency())
+	)
+
+	var query func(batch []string)
+	query = func(batch []string) {
+		values, errs, err := fn(ctx, batch)
+
+		if len(batch) > 1 && accountError(err) {
+			query(batch[:len(batch)/2])
+			query(batch[len(batch)/2:])
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, account := range batch {
+			if err != nil {
+				results[account] = AccountResult[T]{Err: err}
+				continue
+			}
+
+			if v, ok := values[account]; ok {
+				results[account] = AccountResult[T]{Value: v}
+				continue
+			}
+
+			if accountErr, ok := errs[account]; ok {
+				results[account] = AccountResult[T]{Err: accountErr}
+				continue
+			}
+
+			results[account] = AccountResult[T]{Err: missing(account)}
+		}
+	}
+
+	size := opts.batchSize()
+	for start := 0; start < len(accounts); start += size {
+		batch := accounts[start:min(start+size, len(accounts))]
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			for _, account := range batch {
+				results[account] = AccountResult[T]{Err: ctx.Err()}
+			}
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(batch []string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			query(batch)
+		}(batch)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// Reports whether err is a node error about a single account of a
+// request, rather than one failing the request whatever its accounts.
+func accountError(err error) bool {
+	var nodeErr *NodeError
+	if !errors.As(err, &nodeErr) {
+		return false
+	}
+
+	return errors.Is(err, ErrBadAccount) || errors.Is(err, ErrAccountNotFound)
+}
@@ -0,0 +1,143 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+const badAccount = "nano_1111"
+
+// Creates count accounts in a new wallet.
+func createAccounts(t *testing.T, c *rpc.Client, count int) []string {
+	t.Helper()
+	ctx := context.Background()
+
+	wallet, err := c.CreateWallet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts := make([]string, count)
+	for i := range accounts {
+		if accounts[i], err = c.CreateAccount(ctx, wallet, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return accounts
+}
+
+// A bad account only fails its own result, not the rest of its batch.
+func TestBulkBadAccount(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+
+	accounts := createAccounts(t, c, 7)
+	accounts = append(accounts[:3], append([]string{badAccount}, accounts[3:]...)...)
+	opts := rpc.BulkOptions{BatchSize: 4}
+
+	balances := c.BulkAccountsBalances(ctx, accounts, opts)
+	pending := c.BulkAccountsPending(ctx, accounts, 10, "", "", opts)
+
+	for _, account := range accounts {
+		for name, err := range map[string]error{
+			"balances": balances[account].Err,
+			"pending":  pending[account].Err,
+		} {
+			if account == badAccount {
+				if !errors.Is(err, rpc.ErrBadAccount) {
+					t.Errorf("%s of the bad account returned %v, want ErrBadAccount", name, err)
+				}
+			} else if err != nil {
+				t.Errorf("%s of %s returned %v", name, account, err)
+			}
+		}
+	}
+
+	if v := balances[accounts[0]].Value; v["balance"] != "0" {
+		t.Errorf("balance of %s = %v", accounts[0], v)
+	}
+
+	// Two batches, the bad one split into halves and its bad half again.
+	if got := n.Requests("accounts_balances"); got != 6 {
+		t.Errorf("sent %d accounts_balances requests, want 6", got)
+	}
+}
+
+func TestBulkAccountsFrontiers(t *testing.T) {
+	for _, major := range []int{22, 24} {
+		n := rpctest.NewNode()
+		defer n.Close()
+		n.SetVersion(major, 0)
+
+		c := n.Client()
+		ctx := context.Background()
+
+		_, opened := fundedWallet(t, n, c, "10")
+		unopened := createAccounts(t, c, 1)[0]
+
+		r := c.BulkAccountsFrontiers(ctx, []string{opened, unopened, badAccount}, rpc.BulkOptions{BatchSize: 2})
+
+		if res := r[opened]; res.Err != nil || res.Value == "" {
+			t.Errorf("V%d: frontier of an opened account = %q, %v", major, res.Value, res.Err)
+		}
+
+		if err := r[unopened].Err; !errors.Is(err, rpc.ErrAccountNotFound) {
+			t.Errorf("V%d: frontier of an unopened account returned %v, want ErrAccountNotFound", major, err)
+		}
+
+		// Reported by the node itself from V24 on.
+		var nodeErr *rpc.NodeError
+		if got := errors.As(r[unopened].Err, &nodeErr); got != (major >= 24) {
+			t.Errorf("V%d: error of an unopened account is a *NodeError: %v", major, got)
+		}
+		if err := r[badAccount].Err; major >= 24 && !errors.Is(err, rpc.ErrBadAccount) {
+			t.Errorf("V%d: frontier of a bad account returned %v, want ErrBadAccount", major, err)
+		}
+	}
+}
+
+// Errors not about an account fail the whole batch without splitting it.
+func TestBulkRequestError(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	c := n.Client()
+	accounts := createAccounts(t, c, 64)
+	r := c.BulkAccountsPending(context.Background(), accounts, 10, "notanumber", "", rpc.BulkOptions{BatchSize: 32})
+
+	for _, account := range accounts {
+		var nodeErr *rpc.NodeError
+		if err := r[account].Err; !errors.As(err, &nodeErr) || nodeErr.Message != "Bad amount number" {
+			t.Errorf("pending of %s returned %v, want the node's bad amount error", account, err)
+		}
+	}
+	if got := n.Requests("accounts_pending"); got != 2 {
+		t.Errorf("sent %d accounts_pending requests, want 2", got)
+	}
+}
+
+// Errors failing any request don't split the batch.
+func TestBulkControlDisabled(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	n.OnRequest(reply(`{"error": "RPC control is disabled"}`))
+
+	accounts := []string{n.Genesis(), badAccount}
+	r := n.Client().BulkAccountsBalances(context.Background(), accounts, rpc.BulkOptions{})
+
+	for _, account := range accounts {
+		if err := r[account].Err; !errors.Is(err, rpc.ErrControlDisabled) {
+			t.Errorf("balance of %s returned %v, want ErrControlDisabled", account, err)
+		}
+	}
+	if requests := n.Requests("accounts_balances"); requests != 1 {
+		t.Errorf("sent %d requests, want 1", requests)
+	}
+}
@@ -0,0 +1,298 @@
+package rpc
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheStore stores raw responses for Cache.
+// Implementations must be safe for concurrent use.
+type CacheStore interface {
+	// Returns the value stored for key, unless it expired.
+	Get(key string) ([]byte, bool)
+	// Stores value for key. A ttl of zero never expires.
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// Actions whose results change slowly enough to be cached for a short time.
+var volatileActions = map[string]bool{
+	"available_supply": true,
+	"block_count":      true,
+	"block_count_type": true,
+	"frontier_count":   true,
+}
+
+// Cache caches responses of actions that never change, like blocks
+// looked up by hash, and briefly caches counters of the ledger and
+// blocks_info, whose successor changes as the chain grows. Every
+// other action, wallet and control actions included, bypasses it.
+type Cache struct {
+	store CacheStore
+	ttl   time.Duration
+}
+
+// Returns a cache keeping results in store. Counters like block_count
+// and blocks_info are cached for ttl; a ttl of zero disables caching them.
+func NewCache(store CacheStore, ttl time.Duration) *Cache {
+	return &Cache{store: store, ttl: ttl}
+}
+
+// Caches results of the client in c.
+func WithCache(c *Cache) Option {
+	return WithInterceptors(c.Interceptor())
+}
+
+// Returns an interceptor answering from the cache where it can.
+// Only successful responses are cached.
+func (c *Cache) Interceptor() Interceptor {
+	return func(ctx context.Context, action string, payload map[string]interface{}, next Invoker) ([]byte, error) {
+		switch action {
+		case "block", "block_account":
+			hash, ok := payload["hash"].(string)
+			if !ok {
+				break
+			}
+
+			return c.cached(cacheKey(action, payload, hash), 0, func() ([]byte, error) {
+				return next(ctx, action, payload)
+			})
+		case "blocks", "blocks_info":
+			// Pending depends on whether the block was received yet.
+			if action == "blocks_info" && (c.ttl <= 0 || truthy(payload["pending"])) {
+				break
+			}
+
+			hashes, ok := stringList(payload["hashes"])
+			if !ok {
+				break
+			}
+
+			return c.blocks(ctx, action, payload, hashes, next)
+		default:
+			if !volatileActions[action] || c.ttl <= 0 {
+				break
+			}
+
+			return c.cached(cacheKey(action, payload, ""), c.ttl, func() ([]byte, error) {
+				return next(ctx, action, payload)
+			})
+		}
+
+		return next(ctx, action, payload)
+	}
+}
+
+// Returns the value of key from the store or, on a miss, from fetch.
+func (c *Cache) cached(key string, ttl time.Duration, fetch func() ([]byte, error)) ([]byte, error) {
+	if raw, ok := c.store.Get(key); ok {
+		return raw, nil
+	}
+
+	raw, err := fetch()
+	if err == nil {
+		c.store.Set(key, raw, ttl)
+	}
+
+	return raw, err
+}
+
+// Serves blocks and blocks_info per hash, only asking the node for the
+// blocks missing from the cache. Blocks are kept for good, confirmed
+// entries of blocks_info for the ttl of the cache.
+func (c *Cache) blocks(ctx context.Context, action string, payload map[string]interface{}, hashes []string, next Invoker) ([]byte, error) {
+	blocks := make(map[string]json.RawMessage, len(hashes))
+
+	var missing []string
+	for _, hash := range hashes {
+		if raw, ok := c.store.Get(cacheKey(action, payload, hash)); ok {
+			blocks[hash] = raw
+		} else {
+			missing = append(missing, hash)
+		}
+	}
+
+	if len(missing) > 0 {
+		request := make(map[string]interface{}, len(payload))
+		for k, v := range payload {
+			request[k] = v
+		}
+		request["hashes"] = missing
+
+		raw, err := next(ctx, action, request)
+		if err != nil {
+			return raw, err
+		}
+
+		var r struct {
+			Blocks map[string]json.RawMessage `json:"blocks"`
+		}
+		if err = json.Unmarshal(raw, &r); err != nil || r.Blocks == nil {
+			// Leave reporting the malformed response to the caller.
+			return raw, nil
+		}
+
+		ttl := time.Duration(0)
+		if action == "blocks_info" {
+			ttl = c.ttl
+		}
+
+		for hash, block := range r.Blocks {
+			blocks[hash] = block
+			if action == "blocks_info" && unconfirmed(block) {
+				continue
+			}
+			c.store.Set(cacheKey(action, payload, hash), block, ttl)
+		}
+	}
+
+	return json.Marshal(map[string]interface{}{"blocks": blocks})
+}
+
+// Returns a key for the response of action for hash. Other
+// fields of the payload change the response, so they are part
+// of the key too.
+func cacheKey(action string, payload map[string]interface{}, hash string) string {
+	rest := make(map[string]interface{}, len(payload))
+	for k, v := range payload {
+		switch k {
+		case "action", "hash", "hashes":
+		default:
+			rest[k] = v
+		}
+	}
+
+	// Maps marshal with sorted keys, so equal payloads give equal keys.
+	options, _ := json.Marshal(rest)
+
+	return action + "/" + string(options) + "/" + strings.ToUpper(hash)
+}
+
+// Reports whether blocks_info says block is not confirmed yet.
+func unconfirmed(block json.RawMessage) bool {
+	var info struct {
+		Confirmed interface{} `json:"confirmed"`
+	}
+	if json.Unmarshal(block, &info) != nil {
+		return true
+	}
+
+	return info.Confirmed != nil && !truthy(info.Confirmed)
+}
+
+// Reports whether v is a true flag, as a bool or the node's string form.
+func truthy(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true" || v == "1"
+	default:
+		return false
+	}
+}
+
+// Returns v as a list of strings if it is one.
+func stringList(v interface{}) ([]string, bool) {
+	switch v := v.(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		r := make([]string, len(v))
+		for i, s := range v {
+			var ok bool
+			if r[i], ok = s.(string); !ok {
+				return nil, false
+			}
+		}
+		return r, true
+	default:
+		return nil, false
+	}
+}
+
+// LRUCache is an in-memory CacheStore holding up to a fixed
+// number of entries, evicting the least recently used first.
+type LRUCache struct {
+	size int
+
+	mu      sync.Mutex
+	entries *list.List
+	items   map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// Returns a cache holding up to size entries.
+func NewLRUCache(size int) *LRUCache {
+	if size < 1 {
+		size = 1
+	}
+
+	return &LRUCache{
+		size:    size,
+		entries: list.New(),
+		items:   make(map[string]*list.Element),
+	}
+}
+
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := el.Value.(*lruEntry)
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		c.entries.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+
+	c.entries.MoveToFront(el)
+
+	return e.value, true
+}
+
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*lruEntry)
+		e.value, e.expires = value, expires
+		c.entries.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.entries.PushFront(&lruEntry{key: key, value: value, expires: expires})
+
+	for c.entries.Len() > c.size {
+		el := c.entries.Back()
+		c.entries.Remove(el)
+		delete(c.items, el.Value.(*lruEntry).key)
+	}
+}
+
+// Returns the number of entries in the cache.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.entries.Len()
+}
@@ -0,0 +1,114 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+func TestCacheBlocks(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client(rpc.WithCache(rpc.NewCache(rpc.NewLRUCache(16), 0)))
+	ctx := context.Background()
+
+	_, account := fundedWallet(t, n, c, "10")
+	info, err := c.AccountInfo(ctx, account, false, false, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		blocks, err := c.Blocks(ctx, []string{info.OpenBlock})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b := blocks[info.OpenBlock]; b == nil || b.Hash() != info.OpenBlock {
+			t.Fatalf("blocks returned %v", blocks)
+		}
+	}
+
+	if got := n.Requests("blocks"); got != 1 {
+		t.Errorf("node got %d blocks requests, want 1", got)
+	}
+
+	// Without a ttl, blocks_info isn't cached.
+	for i := 0; i < 2; i++ {
+		if _, err = c.BlocksInfo(ctx, []string{info.OpenBlock}, false, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := n.Requests("blocks_info"); got != 2 {
+		t.Errorf("node got %d blocks_info requests, want 2", got)
+	}
+}
+
+// The successor of a confirmed block changes, so blocks_info
+// is only kept for the ttl.
+func TestCacheBlocksInfo(t *testing.T) {
+	const ttl = 50 * time.Millisecond
+
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client(rpc.WithCache(rpc.NewCache(rpc.NewLRUCache(16), ttl)))
+	ctx := context.Background()
+
+	wallet, account := fundedWallet(t, n, c, "10")
+	info, err := c.AccountInfo(ctx, account, false, false, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	successor := func() string {
+		t.Helper()
+
+		blocks, err := c.BlocksInfo(ctx, []string{info.OpenBlock}, false, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if blocks[info.OpenBlock] == nil {
+			t.Fatalf("blocks_info returned %v", blocks)
+		}
+
+		return blocks[info.OpenBlock].Successor
+	}
+
+	const none = "0000000000000000000000000000000000000000000000000000000000000000"
+	if got := successor(); got != none {
+		t.Fatalf("successor of the frontier = %s", got)
+	}
+
+	sent, err := c.Send(ctx, wallet, account, n.Genesis(), "", 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	successor()
+	if got := n.Requests("blocks_info"); got != 1 {
+		t.Errorf("node got %d blocks_info requests within the ttl, want 1", got)
+	}
+
+	time.Sleep(ttl)
+	if got := successor(); got != sent {
+		t.Errorf("successor after the ttl = %s, want %s", got, sent)
+	}
+}
+
+func TestCacheCounters(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client(rpc.WithCache(rpc.NewCache(rpc.NewLRUCache(16), time.Minute)))
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.BlockCount(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := n.Requests("block_count"); got != 1 {
+		t.Errorf("node got %d block_count requests, want 1", got)
+	}
+}
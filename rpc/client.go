@@ -2,224 +2,290 @@ package rpc
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
-	"strconv"
-)
-
-var (
-	client = NewClient("http://localhost:7076")
+	"time"
 )
 
 type Client struct {
-	url string
+	url       string
+	transport Transport
+	// Whether transport was given with WithTransport.
+	customTransport bool
+	wrappers        []func(Transport) Transport
+
+	httpClient   *http.Client
+	roundTripper http.RoundTripper
+	timeout      time.Duration
+	maxResponse  int64
+	header       http.Header
+	userAgent    string
+
+	basicAuth bool
+	username  string
+	password  string
+
+	retry        *RetryPolicy
+	pool         *pool
+	interceptors []Interceptor
+	interceptor  Interceptor
+	limits       *limiter
+	compat       *compat
 }
 
-func NewClient(url string) *Client {
+func NewClient(url string, opts ...Option) *Client {
 	c := &Client{
-		url: url,
+		url:        url,
+		httpClient: http.DefaultClient,
+		header:     make(http.Header),
 	}
 
-	return c
-}
-
-func SetRPCServer(url string) {
-	client.url = url
-}
-
-// Derive deterministic keypair from seed based on index.
-func DeterministicKey(seed string, index int) (map[string]string, error) {
-	payload := map[string]interface{}{
-		"seed":  seed,
-		"index": index,
+	for _, opt := range opts {
+		opt(c)
 	}
 
-	return client.fetchMap("deterministic_key", payload, "")
-}
+	if c.roundTripper != nil {
+		hc := *c.httpClient
+		hc.Transport = c.roundTripper
+		c.httpClient = &hc
+	}
 
-// Generates an adhoc random keypair.
-func KeyCreate() (map[string]string, error) {
-	return client.fetchMap("key_create", nil, "")
-}
+	if c.transport == nil {
+		c.transport = c.newTransport(url)
+	} else {
+		c.customTransport = true
+		c.transport = c.wrap(c.transport)
+	}
 
-// Derives public key and account number from private key.
-func KeyExpand(key string) (map[string]string, error) {
-	payload := map[string]interface{}{
-		"key": key,
+	if len(c.interceptors) > 0 {
+		c.interceptor = ChainInterceptors(c.interceptors...)
 	}
 
-	return client.fetchMap("key_expand", payload, "")
+	return c
 }
 
-// Retrieves unchecked database keys, blocks hashes & a json
-// representations of unchecked pending blocks
-// starting from key up to count (>= v8.0).
-func UncheckedKeys(key string, count int) (map[string]interface{}, error) {
+// Retrieves unchecked database keys, block hashes and the blocks
+// waiting for a dependency, starting from key up to count (>= v8.0).
+// See UncheckedKeysAll to walk all of them.
+func (c *Client) UncheckedKeys(ctx context.Context, key string, count int) ([]UncheckedEntry, error) {
 	payload := map[string]interface{}{
 		"key":   key,
 		"count": count,
 	}
 
-	return client.fetchMapInterface("unchecked_keys", payload, "unchecked")
-}
-
-func (c *Client) call(action string, payload map[string]interface{}) ([]byte, error) {
-	if payload == nil {
-		payload = make(map[string]interface{})
+	var r UncheckedKeysResponse
+	if err := c.fetch(ctx, "unchecked_keys", payload, &r, "unchecked"); err != nil {
+		return nil, err
 	}
 
-	payload["action"] = action
-	body, err := json.Marshal(payload)
+	return []UncheckedEntry(r.Unchecked), nil
+}
+
+// Calls action with params, which may be nil, a map or a struct that
+// encodes to a JSON object, and decodes the response into out unless
+// out is nil. An action key in params must match action. Use it for
+// actions without a method of their own. The call goes through the
+// same retries, interceptors and error handling as every other method.
+func (c *Client) Call(ctx context.Context, action string, params interface{}, out interface{}) error {
+	payload, err := toPayload(action, params)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	res, err := http.Post(c.url, "application/json", bytes.NewBuffer(body))
+	raw, err := c.call(ctx, action, payload)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer res.Body.Close()
 
-	return ioutil.ReadAll(res.Body)
-}
+	if out == nil {
+		return nil
+	}
 
-func (c *Client) fetchMap(action string, payload map[string]interface{}, key string) (map[string]string, error) {
-	raw, err := c.call(action, payload)
-	if err != nil {
-		return nil, err
+	if err = json.Unmarshal(raw, out); err != nil {
+		return malformed(action, err)
 	}
 
-	var r map[string]string
-	if key == "" {
-		if err = json.Unmarshal(raw, &r); err != nil {
-			return nil, err
+	return nil
+}
+
+// Converts params of Call into a payload. Numbers are kept
+// as json.Number so large amounts survive the round trip.
+func toPayload(action string, params interface{}) (map[string]interface{}, error) {
+	var payload map[string]interface{}
+	switch p := params.(type) {
+	case nil:
+		return make(map[string]interface{}), nil
+	case map[string]interface{}:
+		payload = make(map[string]interface{}, len(p))
+		for k, v := range p {
+			payload[k] = v
 		}
-	} else {
-		var data map[string]map[string]string
-		if err = json.Unmarshal(raw, &data); err != nil {
-			return nil, err
+	default:
+		raw, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("rpc: %s: encoding params: %w", action, err)
 		}
 
-		var ok bool
-		r, ok = data[key]
-		if !ok {
-			return nil, fmt.Errorf("Response of %s doesn't contain key %s.\n", action, key)
+		d := json.NewDecoder(bytes.NewReader(raw))
+		d.UseNumber()
+
+		if err = d.Decode(&payload); err != nil || payload == nil {
+			return nil, fmt.Errorf("rpc: %s: params must encode to a JSON object, not %s", action, raw)
 		}
 	}
 
-	return r, nil
+	if a, ok := payload["action"]; ok && a != action {
+		return nil, fmt.Errorf("rpc: %s: params set action to %v", action, a)
+	}
+
+	return payload, nil
 }
 
-func (c *Client) fetchMapInterface(action string, payload map[string]interface{}, key string) (map[string]interface{}, error) {
-	raw, err := c.call(action, payload)
-	if err != nil {
-		return nil, err
+func (c *Client) call(ctx context.Context, action string, payload map[string]interface{}) ([]byte, error) {
+	if payload == nil {
+		payload = make(map[string]interface{})
 	}
 
-	var r map[string]interface{}
-	if key == "" {
-		if err = json.Unmarshal(raw, &r); err != nil {
-			return nil, err
+	if c.retry == nil || !canRetry(action, payload) {
+		return c.invoke(ctx, action, payload)
+	}
+
+	for attempt := 1; ; attempt++ {
+		raw, err := c.invoke(ctx, action, payload)
+		if err == nil || attempt >= c.retry.MaxAttempts || !c.retry.Retryable(err) {
+			return raw, err
 		}
-	} else {
-		var data map[string]map[string]interface{}
-		if err = json.Unmarshal(raw, &data); err != nil {
+
+		// Entries already passed on by a stream can't be taken back.
+		if streamed(ctx) {
+			return raw, err
+		}
+
+		if ctx.Err() != nil {
 			return nil, err
 		}
 
-		var ok bool
-		r, ok = data[key]
-		if !ok {
-			return nil, fmt.Errorf("Response of %s doesn't contain key %s.\n", action, key)
+		if err := sleep(ctx, c.retry.backoff(attempt)); err != nil {
+			return nil, err
 		}
 	}
+}
 
-	return r, nil
+// Runs a single attempt through the interceptor chain.
+func (c *Client) invoke(ctx context.Context, action string, payload map[string]interface{}) ([]byte, error) {
+	if c.interceptor == nil {
+		return c.send(ctx, action, payload)
+	}
+
+	return c.interceptor(ctx, action, payload, c.send)
 }
 
-func (c *Client) isSuccess(action string, payload map[string]interface{}, key string) (bool, error) {
-	r, err := c.fetchMap(action, payload, "")
+// Sends a single request to the node, or to the node of
+// the pool chosen for action.
+func (c *Client) send(ctx context.Context, action string, payload map[string]interface{}) ([]byte, error) {
+	payload["action"] = action
+	body, err := json.Marshal(payload)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	var ok bool
-	if key == "" {
-		_, ok = r["success"]
-	} else {
-		r, exists := r[key]
-		ok = exists && r == "1"
+	if c.limits != nil {
+		release, err := c.limits.acquire(ctx, action)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
 	}
 
-	return ok, nil
+	if c.pool != nil {
+		return c.pool.post(ctx, action, payload, body)
+	}
+
+	return c.post(ctx, action, body)
 }
 
-func (c *Client) fetchString(action string, payload map[string]interface{}, key string) (string, error) {
-	r, err := c.fetchMap(action, payload, "")
+// Sends a single request to the node over its transport.
+func (c *Client) post(ctx context.Context, action string, body []byte) ([]byte, error) {
+	setEndpoint(ctx, c.url)
+
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	if s := streamFrom(ctx); s != nil {
+		if st, ok := c.transport.(StreamTransport); ok {
+			return nil, st.Stream(ctx, action, body, s.consume)
+		}
+	}
+
+	raw, err := c.transport.RoundTrip(ctx, action, body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	val, ok := r[key]
-	if !ok {
-		return "", fmt.Errorf("Response of %s doesn't contain key %s.\n", action, key)
+	if c.maxResponse > 0 && int64(len(raw)) > c.maxResponse {
+		return nil, tooLarge(c.maxResponse)
 	}
 
-	return val, nil
+	if err = checkEnvelope(action, raw); err != nil {
+		return raw, err
+	}
+
+	return raw, nil
 }
 
-func (c *Client) fetchInt(action string, payload map[string]interface{}, key string) (int, error) {
-	r, err := c.fetchMap(action, payload, "")
+func (c *Client) fetchMap(ctx context.Context, action string, payload map[string]interface{}, key string) (map[string]string, error) {
+	raw, err := c.call(ctx, action, payload)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	raw, ok := r[key]
-	if !ok {
-		return 0, fmt.Errorf("Response of %s doesn't contain key %s.\n", action, key)
+	var r map[string]string
+	if key == "" {
+		if err = json.Unmarshal(raw, &r); err != nil {
+			return nil, malformed(action, err)
+		}
+	} else {
+		var data map[string]map[string]string
+		if err = json.Unmarshal(raw, &data); err != nil {
+			return nil, malformed(action, err)
+		}
+
+		var ok bool
+		r, ok = data[key]
+		if !ok {
+			return nil, errMissingKey(action, key)
+		}
 	}
 
-	return strconv.Atoi(raw)
+	return r, nil
 }
 
-func (c *Client) fetchInterface(action string, payload map[string]interface{}, key string) (interface{}, error) {
-	r, err := c.fetchMapInterface(action, payload, "")
+func (c *Client) fetchString(ctx context.Context, action string, payload map[string]interface{}, key string) (string, error) {
+	r, err := c.fetchMap(ctx, action, payload, "")
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	val, ok := r[key]
 	if !ok {
-		return nil, fmt.Errorf("Response of %s doesn't contain key %s.\n", action, key)
+		return "", errMissingKey(action, key)
 	}
 
 	return val, nil
 }
 
-func (c *Client) fetchSlice(action string, payload map[string]interface{}, key string) ([]string, error) {
-	rawVal, err := c.fetchInterface(action, payload, key)
-	if err != nil {
-		return nil, err
+// Decodes a list of the response into v. Nodes send an empty
+// string instead of an empty list, which leaves v untouched.
+func decodeList(raw json.RawMessage, v interface{}) error {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return nil
 	}
 
-	switch val := rawVal.(type) {
-	case []string:
-		return val, nil
-	case string:
-		if val == "" {
-			return []string{}, nil
-		} else {
-			return nil, fmt.Errorf("Key %s in response of %s contains a string instead of slice.\n", key, action)
-		}
-	case []interface{}:
-		v := make([]string, len(val))
-		for i, item := range val {
-			v[i] = item.(string)
-		}
-		return v, nil
-	default:
-		return nil, fmt.Errorf("Key %s in response of %s contains an invalid type %T.\n", key, action, val)
-	}
+	return json.Unmarshal(raw, v)
 }
@@ -0,0 +1,202 @@
+package rpc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+// Canceling the context of a call aborts its HTTP request.
+func TestContextCancel(t *testing.T) {
+	arrived := make(chan struct{})
+	aborted := make(chan struct{})
+
+	n := rpctest.NewNode()
+	defer n.Close()
+	// The node reads the body before calling hooks, so the
+	// server notices the client leaving.
+	n.OnRequest(func(w http.ResponseWriter, r *http.Request, action string) bool {
+		close(arrived)
+
+		select {
+		case <-r.Context().Done():
+			close(aborted)
+		case <-time.After(5 * time.Second):
+		}
+
+		return true
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := n.Client().BlockCount(ctx)
+		errs <- err
+	}()
+
+	<-arrived
+	cancel()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("canceled call returned %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("call still running after its context was canceled")
+	}
+
+	select {
+	case <-aborted:
+	case <-time.After(time.Second):
+		t.Error("node never saw the request aborted")
+	}
+}
+
+// Calls with a context already done never reach the node.
+func TestContextDone(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := n.Client().BlockCount(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("call returned %v, want context.Canceled", err)
+	}
+	if got := n.Requests("block_count"); got != 0 {
+		t.Errorf("node got %d requests", got)
+	}
+}
+
+// Clients of different nodes share no state.
+func TestClientInstances(t *testing.T) {
+	n1 := rpctest.NewNode()
+	defer n1.Close()
+	n2 := rpctest.NewNode()
+	defer n2.Close()
+
+	c1, c2 := rpc.NewClient(n1.URL), rpc.NewClient(n2.URL)
+	ctx := context.Background()
+
+	wallet, err := c1.CreateWallet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = c1.AccountList(ctx, wallet); err != nil {
+		t.Errorf("wallet missing from the node that created it: %v", err)
+	}
+	if _, err = c2.AccountList(ctx, wallet); !errors.Is(err, rpc.ErrWalletNotFound) {
+		t.Errorf("wallet of another node returned %v, want ErrWalletNotFound", err)
+	}
+}
+
+// Replies with body to every request, passing on the request bodies.
+func record(body string) (rpctest.Hook, <-chan []byte) {
+	bodies := make(chan []byte, 1)
+	hook := func(w http.ResponseWriter, r *http.Request, action string) bool {
+		raw, _ := io.ReadAll(r.Body)
+		bodies <- raw
+
+		return reply(body)(w, r, action)
+	}
+
+	return hook, bodies
+}
+
+func TestCallParams(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	hook, bodies := record(`{"count": "7"}`)
+	n.OnRequest(hook)
+	c := n.Client()
+
+	type send struct {
+		Account string      `json:"account"`
+		Amount  *big.Int    `json:"amount"`
+		Raw     json.Number `json:"raw"`
+	}
+	max, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+
+	tests := []struct {
+		name   string
+		params interface{}
+		// Body of the request, empty if Call must fail before sending it.
+		want string
+	}{
+		{"nil", nil, `{"action":"block_count"}`},
+		{"map", map[string]interface{}{"include_cemented": true}, `{"action":"block_count","include_cemented":true}`},
+		{"struct", send{"nano_a", max, "1000000000000000000000000000000"}, `{"account":"nano_a","action":"block_count","amount":340282366920938463463374607431768211455,"raw":1000000000000000000000000000000}`},
+		{"same action", map[string]interface{}{"action": "block_count"}, `{"action":"block_count"}`},
+		{"other action", map[string]interface{}{"action": "stop"}, ""},
+		{"struct action", struct {
+			Action string `json:"action"`
+		}{"stop"}, ""},
+		{"slice", []string{"a"}, ""},
+		{"string", "block_count", ""},
+		{"nil struct", (*send)(nil), ""},
+	}
+
+	for _, tt := range tests {
+		before, _ := json.Marshal(tt.params)
+
+		err := c.Call(context.Background(), "block_count", tt.params, nil)
+		if (err != nil) != (tt.want == "") {
+			t.Errorf("%s: got error %v", tt.name, err)
+		}
+
+		select {
+		case body := <-bodies:
+			if string(body) != tt.want {
+				t.Errorf("%s: sent %s, want %s", tt.name, body, tt.want)
+			}
+		default:
+			if tt.want != "" {
+				t.Errorf("%s: sent nothing, want %s", tt.name, tt.want)
+			}
+		}
+
+		if after, _ := json.Marshal(tt.params); !bytes.Equal(before, after) {
+			t.Errorf("%s: params changed from %s to %s", tt.name, before, after)
+		}
+	}
+}
+
+func TestCallOut(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	n.OnRequest(reply(`{"count": "7"}`))
+	c := n.Client()
+	ctx := context.Background()
+
+	var count struct {
+		Count uint64 `json:"count,string"`
+	}
+	if err := c.Call(ctx, "block_count", nil, &count); err != nil || count.Count != 7 {
+		t.Errorf("got count %d (%v), want 7", count.Count, err)
+	}
+
+	var r map[string]string
+	if err := c.Call(ctx, "block_count", nil, &r); err != nil || r["count"] != "7" {
+		t.Errorf("got %v (%v), want count 7", r, err)
+	}
+
+	if err := c.Call(ctx, "block_count", nil, nil); err != nil {
+		t.Errorf("nil out returned %v", err)
+	}
+
+	var list []string
+	if err := c.Call(ctx, "block_count", nil, &list); !errors.Is(err, rpc.ErrMalformedResponse) {
+		t.Errorf("list out returned %v, want ErrMalformedResponse", err)
+	}
+}
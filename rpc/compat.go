@@ -0,0 +1,187 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// First major version naming the pending actions "receivable".
+const receivableVersion = 23
+
+// Pending actions and the names nodes use for them since V23.
+var receivableNames = map[string]string{
+	"accounts_pending":   "accounts_receivable",
+	"pending":            "receivable",
+	"pending_exists":     "receivable_exists",
+	"search_pending":     "search_receivable",
+	"search_pending_all": "search_receivable_all",
+	"wallet_pending":     "wallet_receivable",
+}
+
+var pendingNames = make(map[string]string, len(receivableNames))
+
+func init() {
+	for pending, receivable := range receivableNames {
+		pendingNames[receivable] = pending
+	}
+}
+
+// Matches the version in node_vendor, e.g. "Nano V23.3" or "RaiBlocks 10.0".
+var vendorVersion = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// Capabilities describes the node a client talks to.
+type Capabilities struct {
+	// Vendor as reported by the node, e.g. "Nano V23.3".
+	Vendor string
+	Major  int
+	Minor  int
+	// Whether the node names the pending actions "receivable".
+	Receivable bool
+}
+
+// Returns the name the node uses for action.
+func (caps Capabilities) Action(action string) string {
+	names := pendingNames
+	if caps.Receivable {
+		names = receivableNames
+	}
+
+	if name, ok := names[action]; ok {
+		return name
+	}
+
+	return action
+}
+
+// Parses the response of version.
+func parseCapabilities(v map[string]string) Capabilities {
+	caps := Capabilities{Vendor: v["node_vendor"]}
+
+	if m := vendorVersion.FindStringSubmatch(caps.Vendor); m != nil {
+		caps.Major, _ = strconv.Atoi(m[1])
+		caps.Minor, _ = strconv.Atoi(m[2])
+	}
+	caps.Receivable = caps.Major >= receivableVersion
+
+	return caps
+}
+
+// Detects the version of the node on the first call and maps actions to
+// the names the node uses, e.g. pending to receivable on V23 and later,
+// so code written against either name works with any node. Actions the
+// node rejects as unknown fail with ErrUnsupported, and without a
+// request once the node rejected them.
+// Nodes of a pool are expected to run the same major version.
+func WithCompatibility() Option {
+	return func(c *Client) {
+		c.compat = &compat{}
+		c.interceptors = append(c.interceptors, c.compat.intercept)
+	}
+}
+
+// Returns the capabilities of the node, detected with Version.
+// Clients with WithCompatibility detect them only once.
+func (c *Client) Capabilities(ctx context.Context) (Capabilities, error) {
+	if c.compat != nil {
+		return c.compat.capabilities(ctx, c.invoke)
+	}
+
+	v, err := c.Version(ctx)
+	if err != nil {
+		return Capabilities{}, err
+	}
+
+	return parseCapabilities(v), nil
+}
+
+type compat struct {
+	mu          sync.Mutex
+	caps        *Capabilities
+	unsupported map[string]bool
+}
+
+// Forgets what was detected, e.g. after the client switched nodes.
+func (p *compat) reset() {
+	p.mu.Lock()
+	p.caps = nil
+	p.unsupported = nil
+	p.mu.Unlock()
+}
+
+// Returns the cached capabilities, detecting them with next if needed.
+// Failed detections are not cached.
+func (p *compat) capabilities(ctx context.Context, next Invoker) (Capabilities, error) {
+	p.mu.Lock()
+	caps := p.caps
+	p.mu.Unlock()
+
+	if caps != nil {
+		return *caps, nil
+	}
+
+	raw, err := next(ctx, "version", make(map[string]interface{}))
+	if err != nil {
+		return Capabilities{}, err
+	}
+
+	var v map[string]string
+	if err = json.Unmarshal(raw, &v); err != nil {
+		return Capabilities{}, malformed("version", err)
+	}
+
+	detected := parseCapabilities(v)
+
+	p.mu.Lock()
+	p.caps = &detected
+	p.mu.Unlock()
+
+	return detected, nil
+}
+
+func (p *compat) intercept(ctx context.Context, action string, payload map[string]interface{}, next Invoker) ([]byte, error) {
+	if action == "version" {
+		return next(ctx, action, payload)
+	}
+
+	caps, err := p.capabilities(ctx, next)
+	if err != nil {
+		return nil, err
+	}
+
+	name := caps.Action(action)
+
+	p.mu.Lock()
+	known := !p.unsupported[name]
+	p.mu.Unlock()
+
+	if !known {
+		return nil, unsupported(action, caps)
+	}
+
+	raw, err := next(ctx, name, payload)
+	if errors.Is(err, ErrUnknownAction) {
+		p.mu.Lock()
+		if p.unsupported == nil {
+			p.unsupported = make(map[string]bool)
+		}
+		p.unsupported[name] = true
+		p.mu.Unlock()
+
+		return raw, fmt.Errorf("%w: %w", ErrUnsupported, err)
+	}
+
+	return raw, err
+}
+
+func unsupported(action string, caps Capabilities) error {
+	if caps.Vendor == "" {
+		return fmt.Errorf("rpc: %s: %w", action, ErrUnsupported)
+	}
+
+	return fmt.Errorf("rpc: %s: %w (%s)", action, ErrUnsupported, caps.Vendor)
+}
@@ -0,0 +1,133 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+// Pending and receivable names both reach the node
+// under the name its version uses.
+func TestCompatibilityNames(t *testing.T) {
+	tests := []struct {
+		major         int
+		sent, notSent string
+		existsSent    string
+		receivable    bool
+	}{
+		{22, "pending", "receivable", "pending_exists", false},
+		{23, "receivable", "pending", "receivable_exists", true},
+		{24, "receivable", "pending", "receivable_exists", true},
+	}
+
+	for _, tt := range tests {
+		n := rpctest.NewNode()
+		defer n.Close()
+		n.SetVersion(tt.major, 0)
+		c := n.Client(rpc.WithCompatibility())
+		ctx := context.Background()
+
+		account := createAccounts(t, c, 1)[0]
+		hash, err := n.Fund(account, "10")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = c.Pending(ctx, account, 10, 0, false); err != nil {
+			t.Fatalf("V%d: pending returned %v", tt.major, err)
+		}
+		var r struct {
+			Blocks []string `json:"blocks"`
+		}
+		if err = c.Call(ctx, "receivable", map[string]interface{}{"account": account, "count": 10}, &r); err != nil {
+			t.Fatalf("V%d: receivable returned %v", tt.major, err)
+		}
+		if len(r.Blocks) != 1 || r.Blocks[0] != hash {
+			t.Errorf("V%d: receivable blocks %v, want %s", tt.major, r.Blocks, hash)
+		}
+
+		exists, err := c.PendingExists(ctx, hash)
+		if err != nil || !exists {
+			t.Errorf("V%d: pending_exists returned %v, %v", tt.major, exists, err)
+		}
+
+		if got := n.Requests(tt.sent); got != 2 {
+			t.Errorf("V%d: node got %d %s requests, want 2", tt.major, got, tt.sent)
+		}
+		if got := n.Requests(tt.notSent); got != 0 {
+			t.Errorf("V%d: node got %d %s requests", tt.major, got, tt.notSent)
+		}
+		if got := n.Requests(tt.existsSent); got != 1 {
+			t.Errorf("V%d: node got %d %s requests, want 1", tt.major, got, tt.existsSent)
+		}
+		if got := n.Requests("version"); got != 1 {
+			t.Errorf("V%d: version detected with %d requests, want 1", tt.major, got)
+		}
+
+		caps, err := c.Capabilities(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if caps.Major != tt.major || caps.Receivable != tt.receivable {
+			t.Errorf("V%d: capabilities %+v", tt.major, caps)
+		}
+	}
+}
+
+// Without WithCompatibility names are sent as given.
+func TestNoCompatibility(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	n.SetVersion(22, 0)
+
+	err := n.Client().Call(context.Background(), "receivable", map[string]interface{}{"account": n.Genesis()}, nil)
+	if !errors.Is(err, rpc.ErrUnknownAction) || errors.Is(err, rpc.ErrUnsupported) {
+		t.Errorf("receivable on V22 returned %v, want ErrUnknownAction", err)
+	}
+	if n.Requests("receivable") != 1 || n.Requests("version") != 0 {
+		t.Errorf("node got %d receivable and %d version requests", n.Requests("receivable"), n.Requests("version"))
+	}
+}
+
+func TestCompatibilityUnsupported(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	n.SetVersion(23, 1)
+	c := n.Client(rpc.WithCompatibility())
+
+	for i := 0; i < 3; i++ {
+		err := c.Call(context.Background(), "bogus", nil, nil)
+		if !errors.Is(err, rpc.ErrUnsupported) {
+			t.Errorf("unknown action returned %v, want ErrUnsupported", err)
+		}
+	}
+
+	if got := n.Requests("bogus"); got != 1 {
+		t.Errorf("node got %d requests of an action it rejected, want 1", got)
+	}
+}
+
+func TestCapabilitiesAction(t *testing.T) {
+	tests := []struct {
+		receivable   bool
+		action, want string
+	}{
+		{false, "pending", "pending"},
+		{false, "wallet_receivable", "wallet_pending"},
+		{false, "search_receivable_all", "search_pending_all"},
+		{true, "accounts_pending", "accounts_receivable"},
+		{true, "receivable_exists", "receivable_exists"},
+		{true, "block_count", "block_count"},
+		{false, "block_count", "block_count"},
+	}
+
+	for _, tt := range tests {
+		caps := rpc.Capabilities{Receivable: tt.receivable}
+		if got := caps.Action(tt.action); got != tt.want {
+			t.Errorf("receivable %v: Action(%s) = %s, want %s", tt.receivable, tt.action, got, tt.want)
+		}
+	}
+}
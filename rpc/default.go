@@ -0,0 +1,80 @@
+package rpc
+
+import (
+	"context"
+)
+
+// Default client used by the package level functions.
+var (
+	client = NewClient("http://localhost:7076")
+)
+
+// Returns the default client used by the package level functions.
+func Default() *Client {
+	return client
+}
+
+// Points the default client at url.
+// Use a unix:// URL to talk to the node over IPC.
+func SetRPCServer(url string) {
+	client.url = url
+	client.transport = client.newTransport(url)
+	if client.compat != nil {
+		client.compat.reset()
+	}
+}
+
+// Calls action on the default client, see Client.Call.
+func Call(action string, params interface{}, out interface{}) error {
+	return client.Call(context.Background(), action, params, out)
+}
+
+// Calls AccountInfo on the default client.
+func AccountInfo(account string, representative, weight, pending, includeConfirmed bool) (*Account, error) {
+	return client.AccountInfo(context.Background(), account, representative, weight, pending, includeConfirmed)
+}
+
+// Calls AccountsInfo on the default client.
+func AccountsInfo(accounts []string, representative, weight, pending, includeConfirmed bool) (map[string]*Account, error) {
+	return client.AccountsInfo(context.Background(), accounts, representative, weight, pending, includeConfirmed)
+}
+
+// Calls AccountHistory on the default client.
+func AccountHistory(account string, count int) ([]map[string]string, error) {
+	return client.AccountHistory(context.Background(), account, count)
+}
+
+// Calls GetBlock on the default client.
+func GetBlock(hash string) (Block, error) {
+	return client.GetBlock(context.Background(), hash)
+}
+
+// Calls BlocksInfo on the default client.
+func BlocksInfo(hashes []string, pending, source bool) (map[string]*BlockInfo, error) {
+	return client.BlocksInfo(context.Background(), hashes, pending, source)
+}
+
+// Calls GetUncheckedBlock on the default client.
+func GetUncheckedBlock(hash string) (Block, error) {
+	return client.GetUncheckedBlock(context.Background(), hash)
+}
+
+// Calls CreateStateBlock on the default client.
+func CreateStateBlock(subtype string, req CreateStateBlockRequest) (string, *StateBlock, error) {
+	return client.CreateStateBlock(context.Background(), subtype, req)
+}
+
+// Calls ProcessBlock on the default client.
+func ProcessBlock(block Block, subtype string, force, async bool) (string, error) {
+	return client.ProcessBlock(context.Background(), block, subtype, force, async)
+}
+
+// Calls Send on the default client.
+func Send(wallet, source, destination, id string, amount int, work string) (string, error) {
+	return client.Send(context.Background(), wallet, source, destination, id, amount, work)
+}
+
+// Calls UncheckedKeys on the default client.
+func UncheckedKeys(key string, count int) ([]UncheckedEntry, error) {
+	return client.UncheckedKeys(context.Background(), key, count)
+}
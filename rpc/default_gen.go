@@ -0,0 +1,422 @@
+// Code generated by rpcgen from actions.json. DO NOT EDIT.
+
+package rpc
+
+import (
+	"context"
+)
+
+// Calls CreateAccount on the default client.
+func CreateAccount(wallet string, work bool) (string, error) {
+	return client.CreateAccount(context.Background(), wallet, work)
+}
+
+// Calls GetAccount on the default client.
+func GetAccount(key string) (string, error) {
+	return client.GetAccount(context.Background(), key)
+}
+
+// Calls AccountBalance on the default client.
+func AccountBalance(account string) (string, string, error) {
+	return client.AccountBalance(context.Background(), account)
+}
+
+// Calls AccountBlockCount on the default client.
+func AccountBlockCount(account string) (int, error) {
+	return client.AccountBlockCount(context.Background(), account)
+}
+
+// Calls AccountKey on the default client.
+func AccountKey(account string) (string, error) {
+	return client.AccountKey(context.Background(), account)
+}
+
+// Calls AccountRepresentative on the default client.
+func AccountRepresentative(account string) (string, error) {
+	return client.AccountRepresentative(context.Background(), account)
+}
+
+// Calls SetAccountRepresentative on the default client.
+func SetAccountRepresentative(wallet, account, representative, work string) (string, error) {
+	return client.SetAccountRepresentative(context.Background(), wallet, account, representative, work)
+}
+
+// Calls AccountWeight on the default client.
+func AccountWeight(account string) (string, error) {
+	return client.AccountWeight(context.Background(), account)
+}
+
+// Calls AccountsBalances on the default client.
+func AccountsBalances(accounts []string) (map[string]map[string]string, error) {
+	return client.AccountsBalances(context.Background(), accounts)
+}
+
+// Calls AccountsFrontiers on the default client.
+func AccountsFrontiers(accounts []string) (map[string]string, error) {
+	return client.AccountsFrontiers(context.Background(), accounts)
+}
+
+// Calls AccountsPending on the default client.
+func AccountsPending(accounts []string, count int, threshold, source string) (map[string]interface{}, error) {
+	return client.AccountsPending(context.Background(), accounts, count, threshold, source)
+}
+
+// Calls Delegators on the default client.
+func Delegators(account string) (map[string]string, error) {
+	return client.Delegators(context.Background(), account)
+}
+
+// Calls DelegatorsCount on the default client.
+func DelegatorsCount(account string) (int, error) {
+	return client.DelegatorsCount(context.Background(), account)
+}
+
+// Calls Frontiers on the default client.
+func Frontiers(account string, count int) (map[string]string, error) {
+	return client.Frontiers(context.Background(), account, count)
+}
+
+// Calls WaitPayment on the default client.
+func WaitPayment(account, amount string, timeout int) (string, error) {
+	return client.WaitPayment(context.Background(), account, amount, timeout)
+}
+
+// Calls ValidateAccountNumber on the default client.
+func ValidateAccountNumber(account string) (bool, error) {
+	return client.ValidateAccountNumber(context.Background(), account)
+}
+
+// Calls Pending on the default client.
+func Pending(account string, count, threshold int, source bool) (interface{}, error) {
+	return client.Pending(context.Background(), account, count, threshold, source)
+}
+
+// Calls GetWork on the default client.
+func GetWork(wallet, account string) (string, error) {
+	return client.GetWork(context.Background(), wallet, account)
+}
+
+// Calls SetWork on the default client.
+func SetWork(wallet, account, work string) (bool, error) {
+	return client.SetWork(context.Background(), wallet, account, work)
+}
+
+// Calls Blocks on the default client.
+func Blocks(hashes []string) (map[string]Block, error) {
+	return client.Blocks(context.Background(), hashes)
+}
+
+// Calls BlockAccount on the default client.
+func BlockAccount(hash string) (string, error) {
+	return client.BlockAccount(context.Background(), hash)
+}
+
+// Calls BlockCount on the default client.
+func BlockCount() (map[string]string, error) {
+	return client.BlockCount(context.Background())
+}
+
+// Calls BlockCountType on the default client.
+func BlockCountType() (map[string]string, error) {
+	return client.BlockCountType(context.Background())
+}
+
+// Calls CreateOpenBlock on the default client.
+func CreateOpenBlock(key, account, representative, source, work string) (string, *LegacyOpenBlock, error) {
+	return client.CreateOpenBlock(context.Background(), key, account, representative, source, work)
+}
+
+// Calls CreateReceiveBlock on the default client.
+func CreateReceiveBlock(wallet, account, source, previous, work string) (string, *LegacyReceiveBlock, error) {
+	return client.CreateReceiveBlock(context.Background(), wallet, account, source, previous, work)
+}
+
+// Calls CreateSendBlock on the default client.
+func CreateSendBlock(wallet, account, destination, balance, amount, previous, work string) (string, *LegacySendBlock, error) {
+	return client.CreateSendBlock(context.Background(), wallet, account, destination, balance, amount, previous, work)
+}
+
+// Calls CreateChangeBlock on the default client.
+func CreateChangeBlock(wallet, account, representative, previous, work string) (string, *LegacyChangeBlock, error) {
+	return client.CreateChangeBlock(context.Background(), wallet, account, representative, previous, work)
+}
+
+// Calls PendingExists on the default client.
+func PendingExists(hash string) (bool, error) {
+	return client.PendingExists(context.Background(), hash)
+}
+
+// Calls CancelWork on the default client.
+func CancelWork(hash string) error {
+	return client.CancelWork(context.Background(), hash)
+}
+
+// Calls GenerateWork on the default client.
+func GenerateWork(hash string) (string, error) {
+	return client.GenerateWork(context.Background(), hash)
+}
+
+// Calls ValidateWork on the default client.
+func ValidateWork(work, hash string) (bool, error) {
+	return client.ValidateWork(context.Background(), work, hash)
+}
+
+// Calls Successors on the default client.
+func Successors(block string, count int) ([]string, error) {
+	return client.Successors(context.Background(), block, count)
+}
+
+// Calls Chain on the default client.
+func Chain(block string, count int) ([]string, error) {
+	return client.Chain(context.Background(), block, count)
+}
+
+// Calls History on the default client.
+func History(hash string, count int) ([]map[string]string, error) {
+	return client.History(context.Background(), hash, count)
+}
+
+// Calls AvailableSupply on the default client.
+func AvailableSupply() (string, error) {
+	return client.AvailableSupply(context.Background())
+}
+
+// Calls FrontierCount on the default client.
+func FrontierCount() (int, error) {
+	return client.FrontierCount(context.Background())
+}
+
+// Calls Representatives on the default client.
+func Representatives(count int, sorting bool) (map[string]string, error) {
+	return client.Representatives(context.Background(), count, sorting)
+}
+
+// Calls Ledger on the default client.
+func Ledger(account string, count int, representative, weight, pending, sorting bool) (map[string]*Account, error) {
+	return client.Ledger(context.Background(), account, count, representative, weight, pending, sorting)
+}
+
+// Calls GetReceiveMinimum on the default client.
+func GetReceiveMinimum() (string, error) {
+	return client.GetReceiveMinimum(context.Background())
+}
+
+// Calls SetReceiveMinimum on the default client.
+func SetReceiveMinimum(amount string) (bool, error) {
+	return client.SetReceiveMinimum(context.Background(), amount)
+}
+
+// Calls SearchAllPending on the default client.
+func SearchAllPending() (bool, error) {
+	return client.SearchAllPending(context.Background())
+}
+
+// Calls UncheckedBlocks on the default client.
+func UncheckedBlocks(count int) (map[string]Block, error) {
+	return client.UncheckedBlocks(context.Background(), count)
+}
+
+// Calls ClearUncheckedBlocks on the default client.
+func ClearUncheckedBlocks() (bool, error) {
+	return client.ClearUncheckedBlocks(context.Background())
+}
+
+// Calls SendKeepalive on the default client.
+func SendKeepalive(address string, port int) error {
+	return client.SendKeepalive(context.Background(), address, port)
+}
+
+// Calls Peers on the default client.
+func Peers() (map[string]string, error) {
+	return client.Peers(context.Background())
+}
+
+// Calls AddWorkPeer on the default client.
+func AddWorkPeer(address, port string) (bool, error) {
+	return client.AddWorkPeer(context.Background(), address, port)
+}
+
+// Calls GetWorkPeers on the default client.
+func GetWorkPeers() ([]string, error) {
+	return client.GetWorkPeers(context.Background())
+}
+
+// Calls ClearWorkPeers on the default client.
+func ClearWorkPeers() (bool, error) {
+	return client.ClearWorkPeers(context.Background())
+}
+
+// Calls Bootstrap on the default client.
+func Bootstrap(address string, port int) (bool, error) {
+	return client.Bootstrap(context.Background(), address, port)
+}
+
+// Calls BootstrapAny on the default client.
+func BootstrapAny() (bool, error) {
+	return client.BootstrapAny(context.Background())
+}
+
+// Calls Republish on the default client.
+func Republish(hash string, count, sources, destinations int) ([]string, error) {
+	return client.Republish(context.Background(), hash, count, sources, destinations)
+}
+
+// Calls Version on the default client.
+func Version() (map[string]string, error) {
+	return client.Version(context.Background())
+}
+
+// Calls Stop on the default client.
+func Stop() (bool, error) {
+	return client.Stop(context.Background())
+}
+
+// Calls AccountList on the default client.
+func AccountList(wallet string) ([]string, error) {
+	return client.AccountList(context.Background(), wallet)
+}
+
+// Calls MoveAccounts on the default client.
+func MoveAccounts(wallet, source string, accounts []string) (bool, error) {
+	return client.MoveAccounts(context.Background(), wallet, source, accounts)
+}
+
+// Calls RemoveAccount on the default client.
+func RemoveAccount(wallet, account string) (bool, error) {
+	return client.RemoveAccount(context.Background(), wallet, account)
+}
+
+// Calls CreateAccounts on the default client.
+func CreateAccounts(wallet string, count int, work bool) ([]string, error) {
+	return client.CreateAccounts(context.Background(), wallet, count, work)
+}
+
+// Calls BeginPayment on the default client.
+func BeginPayment(wallet string) (string, error) {
+	return client.BeginPayment(context.Background(), wallet)
+}
+
+// Calls InitPayment on the default client.
+func InitPayment(wallet string) (string, error) {
+	return client.InitPayment(context.Background(), wallet)
+}
+
+// Calls EndPayment on the default client.
+func EndPayment(wallet, account string) error {
+	return client.EndPayment(context.Background(), wallet, account)
+}
+
+// Calls ReceiveBlock on the default client.
+func ReceiveBlock(wallet, account, block, work string) (string, error) {
+	return client.ReceiveBlock(context.Background(), wallet, account, block, work)
+}
+
+// Calls WalletRepresentative on the default client.
+func WalletRepresentative(wallet string) (string, error) {
+	return client.WalletRepresentative(context.Background(), wallet)
+}
+
+// Calls SetWalletRepresentative on the default client.
+func SetWalletRepresentative(wallet, representative string) (bool, error) {
+	return client.SetWalletRepresentative(context.Background(), wallet, representative)
+}
+
+// Calls SearchPending on the default client.
+func SearchPending(wallet string) (bool, error) {
+	return client.SearchPending(context.Background(), wallet)
+}
+
+// Calls WalletAdd on the default client.
+func WalletAdd(wallet, key string, work bool) (string, error) {
+	return client.WalletAdd(context.Background(), wallet, key, work)
+}
+
+// Calls WalletTotalBalance on the default client.
+func WalletTotalBalance(wallet string) (map[string]string, error) {
+	return client.WalletTotalBalance(context.Background(), wallet)
+}
+
+// Calls WalletBalances on the default client.
+func WalletBalances(wallet string, threshold int) (map[string]map[string]string, error) {
+	return client.WalletBalances(context.Background(), wallet, threshold)
+}
+
+// Calls ChangeWalletSeed on the default client.
+func ChangeWalletSeed(wallet, seed string) (bool, error) {
+	return client.ChangeWalletSeed(context.Background(), wallet, seed)
+}
+
+// Calls WalletContains on the default client.
+func WalletContains(wallet, account string) (bool, error) {
+	return client.WalletContains(context.Background(), wallet, account)
+}
+
+// Calls CreateWallet on the default client.
+func CreateWallet() (string, error) {
+	return client.CreateWallet(context.Background())
+}
+
+// Calls DestroyWallet on the default client.
+func DestroyWallet(wallet string) error {
+	return client.DestroyWallet(context.Background(), wallet)
+}
+
+// Calls ExportWallet on the default client.
+func ExportWallet(wallet string) (string, error) {
+	return client.ExportWallet(context.Background(), wallet)
+}
+
+// Calls WalletFrontiers on the default client.
+func WalletFrontiers(wallet string) (map[string]string, error) {
+	return client.WalletFrontiers(context.Background(), wallet)
+}
+
+// Calls WalletPending on the default client.
+func WalletPending(wallet string, count, threshold int, source bool) (map[string]interface{}, error) {
+	return client.WalletPending(context.Background(), wallet, count, threshold, source)
+}
+
+// Calls WalletRepublish on the default client.
+func WalletRepublish(wallet string, count int) ([]string, error) {
+	return client.WalletRepublish(context.Background(), wallet, count)
+}
+
+// Calls WalletWorkGet on the default client.
+func WalletWorkGet(wallet string) (map[string]string, error) {
+	return client.WalletWorkGet(context.Background(), wallet)
+}
+
+// Calls ChangeWalletPassword on the default client.
+func ChangeWalletPassword(wallet, password string) (bool, error) {
+	return client.ChangeWalletPassword(context.Background(), wallet, password)
+}
+
+// Calls EnterWalletPassword on the default client.
+func EnterWalletPassword(wallet, password string) (bool, error) {
+	return client.EnterWalletPassword(context.Background(), wallet, password)
+}
+
+// Calls WalletPasswordValid on the default client.
+func WalletPasswordValid(wallet, password string) (bool, error) {
+	return client.WalletPasswordValid(context.Background(), wallet, password)
+}
+
+// Calls IsWalletLocked on the default client.
+func IsWalletLocked(wallet string) (bool, error) {
+	return client.IsWalletLocked(context.Background(), wallet)
+}
+
+// Calls DeterministicKey on the default client.
+func DeterministicKey(seed string, index int) (map[string]string, error) {
+	return client.DeterministicKey(context.Background(), seed, index)
+}
+
+// Calls KeyCreate on the default client.
+func KeyCreate() (map[string]string, error) {
+	return client.KeyCreate(context.Background())
+}
+
+// Calls KeyExpand on the default client.
+func KeyExpand(key string) (map[string]string, error) {
+	return client.KeyExpand(context.Background(), key)
+}
@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for common node failures. A *NodeError carrying one of
+// the known messages matches the corresponding sentinel with errors.Is.
+var (
+	ErrWalletLocked        = errors.New("wallet is locked")
+	ErrWalletNotFound      = errors.New("wallet not found")
+	ErrBadWallet           = errors.New("bad wallet number")
+	ErrBadAccount          = errors.New("bad account number")
+	ErrAccountNotFound     = errors.New("account not found")
+	ErrBlockNotFound       = errors.New("block not found")
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrUnknownAction       = errors.New("unknown action")
+	ErrControlDisabled     = errors.New("rpc control is disabled")
+
+	// Returned (wrapped) when a response is missing an expected key
+	// or holds a value of an unexpected type.
+	ErrMalformedResponse = errors.New("malformed response")
+
+	// Returned (wrapped) when a response exceeds the size
+	// set with WithMaxResponseSize.
+	ErrResponseTooLarge = errors.New("response too large")
+
+	// Returned (wrapped) by clients with WithCompatibility
+	// for actions the node does not have.
+	ErrUnsupported = errors.New("action not supported by the node")
+)
+
+// Maps lower cased node error messages to their sentinel errors.
+var nodeErrors = map[string]error{
+	"wallet is locked":            ErrWalletLocked,
+	"wallet locked":               ErrWalletLocked,
+	"wallet not found":            ErrWalletNotFound,
+	"bad wallet number":           ErrBadWallet,
+	"bad account number":          ErrBadAccount,
+	"account not found":           ErrAccountNotFound,
+	"account not found in wallet": ErrAccountNotFound,
+	"block not found":             ErrBlockNotFound,
+	"insufficient balance":        ErrInsufficientBalance,
+	"unknown command":             ErrUnknownAction,
+	"rpc control is disabled":     ErrControlDisabled,
+}
+
+// NodeError is returned when the node replies with an error envelope
+// such as {"error": "Wallet is locked"}.
+type NodeError struct {
+	Action  string
+	Message string
+}
+
+func (e *NodeError) Error() string {
+	return fmt.Sprintf("rpc: %s: %s", e.Action, e.Message)
+}
+
+// Returns the sentinel error matching the node message, if any.
+func (e *NodeError) Unwrap() error {
+	return nodeErrors[strings.ToLower(strings.TrimSpace(e.Message))]
+}
+
+// StatusError is returned when the node (or a proxy in front of it)
+// replies with a non-2xx HTTP status and no error envelope.
+type StatusError struct {
+	Action     string
+	StatusCode int
+	Body       []byte
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("rpc: %s: unexpected status %d %s", e.Action, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Checks a raw response for the node's error envelope.
+func checkEnvelope(action string, raw []byte) error {
+	var envelope struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(raw, &envelope) == nil && envelope.Error != "" {
+		return &NodeError{Action: action, Message: envelope.Error}
+	}
+
+	return nil
+}
+
+func errMissingKey(action, key string) error {
+	return fmt.Errorf("%w: response of %s doesn't contain key %s", ErrMalformedResponse, action, key)
+}
+
+// Wraps err, the failure to decode the response of action,
+// with ErrMalformedResponse.
+func malformed(action string, err error) error {
+	return fmt.Errorf("%w: response of %s: %v", ErrMalformedResponse, action, err)
+}
@@ -0,0 +1,133 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+func TestNodeErrors(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+
+	wallet, account := fundedWallet(t, n, c, "10")
+	locked, _ := fundedWallet(t, n, c, "10")
+	if err := n.Lock(locked); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		action string
+		call   func() error
+		want   error
+	}{
+		{"account_list", func() error {
+			_, err := c.AccountList(ctx, "000000000000000000000000000000000000000000000000000000000000000F")
+			return err
+		}, rpc.ErrWalletNotFound},
+		{"account_balance", func() error {
+			_, _, err := c.AccountBalance(ctx, "nano_1111")
+			return err
+		}, rpc.ErrBadAccount},
+		{"send", func() error {
+			_, err := c.Send(ctx, wallet, account, n.Genesis(), rpc.NewSendID(), 1000, "")
+			return err
+		}, rpc.ErrInsufficientBalance},
+		{"bogus", func() error {
+			return c.Call(ctx, "bogus", nil, nil)
+		}, rpc.ErrUnknownAction},
+	}
+
+	for _, tt := range tests {
+		err := tt.call()
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s returned %v, want %v", tt.action, err, tt.want)
+		}
+
+		var nodeErr *rpc.NodeError
+		if !errors.As(err, &nodeErr) || nodeErr.Action != tt.action {
+			t.Errorf("%s returned %#v, want a *NodeError of the action", tt.action, err)
+		}
+	}
+
+	// Messages without a sentinel match none.
+	err := c.Call(ctx, "process", map[string]interface{}{"block": "{}"}, nil)
+	var nodeErr *rpc.NodeError
+	if !errors.As(err, &nodeErr) || errors.Unwrap(err) != nil {
+		t.Errorf("invalid block returned %#v", err)
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	n.OnRequest(failFirst("block_count", 1))
+
+	_, err := n.Client().BlockCount(context.Background())
+
+	var statusErr *rpc.StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("got %#v, want a *StatusError", err)
+	}
+	if statusErr.Action != "block_count" || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status error %+v", statusErr)
+	}
+}
+
+// Returns a hook replying body to every request.
+func reply(body string) rpctest.Hook {
+	return func(w http.ResponseWriter, r *http.Request, action string) bool {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+
+		return true
+	}
+}
+
+func TestMalformedResponse(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		body string
+		call func(*rpc.Client) error
+	}{
+		{"missing key", `{"balance": "1"}`, func(c *rpc.Client) error {
+			_, _, err := c.AccountBalance(ctx, "nano_1111")
+			return err
+		}},
+		{"wrong type", `{"balance": 1, "pending": "0"}`, func(c *rpc.Client) error {
+			_, _, err := c.AccountBalance(ctx, "nano_1111")
+			return err
+		}},
+		{"wrong map type", `{"count": 1}`, func(c *rpc.Client) error {
+			_, err := c.BlockCount(ctx)
+			return err
+		}},
+		{"not an object", `[]`, func(c *rpc.Client) error {
+			_, err := c.AccountList(ctx, "0")
+			return err
+		}},
+		{"call", `"1"`, func(c *rpc.Client) error {
+			var out struct{}
+			return c.Call(ctx, "block_count", nil, &out)
+		}},
+	}
+
+	for _, tt := range tests {
+		n.OnRequest(reply(tt.body))
+
+		if err := tt.call(c); !errors.Is(err, rpc.ErrMalformedResponse) {
+			t.Errorf("%s: got %v, want ErrMalformedResponse", tt.name, err)
+		}
+	}
+}
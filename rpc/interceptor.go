@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"context"
+)
+
+// Invoker sends an action with its payload to the node
+// and returns the raw response.
+type Invoker func(ctx context.Context, action string, payload map[string]interface{}) ([]byte, error)
+
+// Interceptor wraps every attempt of every RPC call. It sees the action
+// name and payload before the request is sent and may mutate the payload,
+// short-circuit by returning without calling next, or inspect the raw
+// response and error returned by next. Node errors are returned together
+// with the raw response that carried them.
+type Interceptor func(ctx context.Context, action string, payload map[string]interface{}, next Invoker) ([]byte, error)
+
+// Adds interceptors to the client. The first interceptor is the
+// outermost one, i.e. it runs first and sees the response last.
+// Retries happen outside the chain, so every attempt passes through it.
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(c *Client) {
+		c.interceptors = append(c.interceptors, interceptors...)
+	}
+}
+
+// Composes interceptors into one, the first being the outermost.
+func ChainInterceptors(interceptors ...Interceptor) Interceptor {
+	return func(ctx context.Context, action string, payload map[string]interface{}, next Invoker) ([]byte, error) {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			next = bind(interceptors[i], next)
+		}
+
+		return next(ctx, action, payload)
+	}
+}
+
+func bind(interceptor Interceptor, next Invoker) Invoker {
+	return func(ctx context.Context, action string, payload map[string]interface{}) ([]byte, error) {
+		return interceptor(ctx, action, payload, next)
+	}
+}
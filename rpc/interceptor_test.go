@@ -0,0 +1,115 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+// Returns an interceptor appending name to log on the way in and out.
+func logInterceptor(name string, log *[]string) rpc.Interceptor {
+	return func(ctx context.Context, action string, payload map[string]interface{}, next rpc.Invoker) ([]byte, error) {
+		*log = append(*log, name+">")
+		raw, err := next(ctx, action, payload)
+		*log = append(*log, "<"+name)
+
+		return raw, err
+	}
+}
+
+func TestInterceptorOrder(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	var log []string
+	c := n.Client(
+		rpc.WithInterceptors(logInterceptor("a", &log), rpc.ChainInterceptors(logInterceptor("b", &log), logInterceptor("c", &log))),
+		rpc.WithInterceptors(logInterceptor("d", &log)),
+	)
+	if _, err := c.BlockCount(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"a>", "b>", "c>", "d>", "<d", "<c", "<b", "<a"}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("interceptors ran in order %v, want %v", log, want)
+	}
+}
+
+func TestInterceptorPayload(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	ctx := context.Background()
+
+	wallet, err := n.Client().CreateWallet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Changes to the payload reach the node.
+	c := n.Client(rpc.WithInterceptors(func(ctx context.Context, action string, payload map[string]interface{}, next rpc.Invoker) ([]byte, error) {
+		payload["wallet"] = wallet
+		return next(ctx, action, payload)
+	}))
+	if _, err = c.AccountList(ctx, "0"); err != nil {
+		t.Errorf("call with the wallet set by an interceptor returned %v", err)
+	}
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	c := n.Client(rpc.WithInterceptors(func(ctx context.Context, action string, payload map[string]interface{}, next rpc.Invoker) ([]byte, error) {
+		return []byte(`{"count": "42", "unchecked": "0", "cemented": "42"}`), nil
+	}))
+
+	counts, err := c.BlockCount(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts["count"] != "42" {
+		t.Errorf("got %v, want the response of the interceptor", counts)
+	}
+	if got := n.Requests("block_count"); got != 0 {
+		t.Errorf("node got %d requests", got)
+	}
+}
+
+// Every attempt passes through the chain, and node errors
+// come with the response carrying them.
+func TestInterceptorAttempts(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	n.OnRequest(failFirst("block_count", 2))
+
+	var attempts int
+	var errs []error
+	record := func(ctx context.Context, action string, payload map[string]interface{}, next rpc.Invoker) ([]byte, error) {
+		raw, err := next(ctx, action, payload)
+		attempts++
+		errs = append(errs, err)
+
+		if err != nil && action == "account_list" && raw == nil {
+			t.Errorf("node error %v came without its response", err)
+		}
+
+		return raw, err
+	}
+
+	c := n.Client(testRetry, rpc.WithInterceptors(record))
+	if _, err := c.BlockCount(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 || errs[0] == nil || errs[2] != nil {
+		t.Errorf("interceptor saw %d attempts failing with %v", attempts, errs)
+	}
+
+	if _, err := c.AccountList(context.Background(), "0"); !errors.Is(err, rpc.ErrBadWallet) {
+		t.Errorf("bad wallet returned %v, want ErrBadWallet", err)
+	}
+}
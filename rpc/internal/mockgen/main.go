@@ -0,0 +1,405 @@
+// Command mockgen writes a mock of an interface of the rpc package.
+//
+// Every method of the mock calls the function field named after it,
+// e.g. AccountInfoFunc, and fails with rpcmock.ErrNotMocked if the
+// field is nil. Calls are recorded for later inspection.
+//
+// Usage, from the directory of the mock package:
+//
+//	go run ../internal/mockgen -src .. -iface API -o mock.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	src := flag.String("src", "..", "directory of the package declaring the interface")
+	iface := flag.String("iface", "API", "interface to mock")
+	out := flag.String("o", "mock.go", "output file")
+	pkg := flag.String("pkg", "rpcmock", "package of the mock")
+	flag.Parse()
+
+	g, err := load(*src)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	methods, err := g.methods(*iface)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	code, err := g.generate(*pkg, *iface, methods)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = os.WriteFile(*out, code, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type generator struct {
+	fset *token.FileSet
+	// Name and import path of the source package.
+	name, path string
+	// Types declared by the source package.
+	types map[string]bool
+	// Interfaces declared by the source package.
+	interfaces map[string]*ast.InterfaceType
+	// Import paths by package name, from the imports of the source files.
+	imports map[string]string
+	// Imports used by the generated code.
+	used map[string]bool
+}
+
+func load(dir string) (*generator, error) {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		fset:       fset,
+		types:      make(map[string]bool),
+		interfaces: make(map[string]*ast.InterfaceType),
+		imports:    make(map[string]string),
+		used:       make(map[string]bool),
+	}
+
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		g.name = f.Name.Name
+
+		for _, imp := range f.Imports {
+			p, _ := strconv.Unquote(imp.Path.Value)
+			name := p[strings.LastIndex(p, "/")+1:]
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			g.imports[name] = p
+		}
+
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				g.types[ts.Name.Name] = true
+				if it, ok := ts.Type.(*ast.InterfaceType); ok {
+					g.interfaces[ts.Name.Name] = it
+				}
+			}
+		}
+	}
+
+	if g.name == "" {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	g.path, err = importPath(abs)
+
+	return g, err
+}
+
+// Derives the import path of dir from the go.mod above it.
+func importPath(dir string) (string, error) {
+	for d := dir; ; d = filepath.Dir(d) {
+		raw, err := os.ReadFile(filepath.Join(d, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(raw), "\n") {
+				if mod, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					rel, _ := filepath.Rel(d, dir)
+					return filepath.ToSlash(filepath.Join(strings.TrimSpace(mod), rel)), nil
+				}
+			}
+		}
+
+		if filepath.Dir(d) == d {
+			return "", fmt.Errorf("no go.mod above %s", dir)
+		}
+	}
+}
+
+type method struct {
+	name string
+	typ  *ast.FuncType
+}
+
+// Returns the methods of iface, including those of embedded
+// interfaces, in the order they are declared.
+func (g *generator) methods(iface string) ([]method, error) {
+	it, ok := g.interfaces[iface]
+	if !ok {
+		return nil, fmt.Errorf("interface %s not found", iface)
+	}
+
+	var methods []method
+	for _, field := range it.Methods.List {
+		switch t := field.Type.(type) {
+		case *ast.FuncType:
+			for _, name := range field.Names {
+				methods = append(methods, method{name: name.Name, typ: t})
+			}
+		case *ast.Ident:
+			embedded, err := g.methods(t.Name)
+			if err != nil {
+				return nil, err
+			}
+			methods = append(methods, embedded...)
+		default:
+			return nil, fmt.Errorf("%s: unsupported embedded type %T", iface, t)
+		}
+	}
+
+	return methods, nil
+}
+
+func (g *generator) generate(pkg, iface string, methods []method) ([]byte, error) {
+	var body bytes.Buffer
+
+	fmt.Fprintf(&body, "// %s is a mock of %s.%s.\n", iface, g.name, iface)
+	fmt.Fprintf(&body, "type %s struct {\n", iface)
+	for _, m := range methods {
+		fmt.Fprintf(&body, "%sFunc func%s\n", m.name, g.signature(m.typ, false))
+	}
+	fmt.Fprintf(&body, "\nmu sync.Mutex\ncalls []Call\n}\n\n")
+	fmt.Fprintf(&body, "var _ %s.%s = (*%s)(nil)\n", g.name, iface, iface)
+	g.used[g.path] = true
+	g.used["sync"] = true
+
+	for _, m := range methods {
+		g.method(&body, iface, m)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by mockgen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+
+	// Standard library first, like goimports.
+	var std, other []string
+	for p := range g.used {
+		if strings.Contains(strings.SplitN(p, "/", 2)[0], ".") {
+			other = append(other, p)
+		} else {
+			std = append(std, p)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+
+	for _, p := range std {
+		fmt.Fprintf(&out, "%q\n", p)
+	}
+	if len(std) > 0 && len(other) > 0 {
+		fmt.Fprintln(&out)
+	}
+	for _, p := range other {
+		fmt.Fprintf(&out, "%q\n", p)
+	}
+	fmt.Fprintf(&out, ")\n\n")
+	out.Write(body.Bytes())
+
+	return format.Source(out.Bytes())
+}
+
+func (g *generator) method(w *bytes.Buffer, iface string, m method) {
+	params := names(m.typ.Params, "p")
+
+	fmt.Fprintf(w, "\nfunc (m *%s) %s%s {\n", iface, m.name, g.signature(m.typ, true))
+
+	var args []string
+	for _, p := range params {
+		if p != "ctx" {
+			args = append(args, p)
+		}
+	}
+	fmt.Fprintf(w, "m.record(%q, %s)\n", m.name, strings.Join(append([]string{"[]interface{}{"}, strings.Join(args, ", ")+"}"), ""))
+
+	fmt.Fprintf(w, "if m.%sFunc == nil {\n", m.name)
+	results := flatten(m.typ.Results)
+	if len(results) == 0 || !isError(results[len(results)-1]) {
+		fmt.Fprintf(w, "panic(notMocked(%q))\n}\n", m.name)
+	} else {
+		var zero []string
+		for i, r := range results[:len(results)-1] {
+			fmt.Fprintf(w, "var r%d %s\n", i, g.expr(r))
+			zero = append(zero, fmt.Sprintf("r%d", i))
+		}
+		zero = append(zero, fmt.Sprintf("notMocked(%q)", m.name))
+		fmt.Fprintf(w, "return %s\n}\n", strings.Join(zero, ", "))
+	}
+
+	call := fmt.Sprintf("m.%sFunc(%s)", m.name, strings.Join(params, ", "))
+	if len(results) == 0 {
+		fmt.Fprintf(w, "%s\n}\n", call)
+	} else {
+		fmt.Fprintf(w, "return %s\n}\n", call)
+	}
+}
+
+// Returns the parameters and results of t, qualified for the
+// mock package. Parameters are named if named is set.
+func (g *generator) signature(t *ast.FuncType, named bool) string {
+	var params []string
+	pnames := names(t.Params, "p")
+	for i, typ := range flatten(t.Params) {
+		if named {
+			params = append(params, pnames[i]+" "+g.expr(typ))
+		} else {
+			params = append(params, g.expr(typ))
+		}
+	}
+
+	var results []string
+	for _, typ := range flatten(t.Results) {
+		results = append(results, g.expr(typ))
+	}
+
+	s := "(" + strings.Join(params, ", ") + ")"
+	switch len(results) {
+	case 0:
+	case 1:
+		s += " " + results[0]
+	default:
+		s += " (" + strings.Join(results, ", ") + ")"
+	}
+
+	return s
+}
+
+// Returns the names of the fields, inventing them for unnamed ones.
+func names(fields *ast.FieldList, prefix string) []string {
+	var r []string
+	if fields == nil {
+		return r
+	}
+
+	for _, f := range fields.List {
+		if len(f.Names) == 0 {
+			r = append(r, fmt.Sprintf("%s%d", prefix, len(r)))
+			continue
+		}
+		for _, n := range f.Names {
+			r = append(r, n.Name)
+		}
+	}
+
+	return r
+}
+
+// Returns one type per field, repeating the type of grouped names.
+func flatten(fields *ast.FieldList) []ast.Expr {
+	var r []ast.Expr
+	if fields == nil {
+		return r
+	}
+
+	for _, f := range fields.List {
+		n := len(f.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			r = append(r, f.Type)
+		}
+	}
+
+	return r
+}
+
+func isError(e ast.Expr) bool {
+	id, ok := e.(*ast.Ident)
+
+	return ok && id.Name == "error"
+}
+
+// Prints e, qualifying the types of the source package
+// and noting the imports it uses.
+func (g *generator) expr(e ast.Expr) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, g.fset, g.qualify(e))
+
+	return buf.String()
+}
+
+func (g *generator) qualify(e ast.Expr) ast.Expr {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if g.types[t.Name] {
+			g.used[g.path] = true
+			return &ast.SelectorExpr{X: ast.NewIdent(g.name), Sel: ast.NewIdent(t.Name)}
+		}
+		return t
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok {
+			if p, ok := g.imports[x.Name]; ok {
+				g.used[p] = true
+			}
+		}
+		return t
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: g.qualify(t.X)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: t.Len, Elt: g.qualify(t.Elt)}
+	case *ast.MapType:
+		return &ast.MapType{Key: g.qualify(t.Key), Value: g.qualify(t.Value)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: g.qualify(t.Elt)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: t.Dir, Value: g.qualify(t.Value)}
+	case *ast.IndexExpr:
+		return &ast.IndexExpr{X: g.qualify(t.X), Index: g.qualify(t.Index)}
+	case *ast.IndexListExpr:
+		indices := make([]ast.Expr, len(t.Indices))
+		for i, index := range t.Indices {
+			indices[i] = g.qualify(index)
+		}
+		return &ast.IndexListExpr{X: g.qualify(t.X), Indices: indices}
+	case *ast.FuncType:
+		return &ast.FuncType{Params: g.qualifyFields(t.Params), Results: g.qualifyFields(t.Results)}
+	default:
+		return e
+	}
+}
+
+func (g *generator) qualifyFields(fields *ast.FieldList) *ast.FieldList {
+	if fields == nil {
+		return nil
+	}
+
+	r := &ast.FieldList{}
+	for _, f := range fields.List {
+		r.List = append(r.List, &ast.Field{Names: f.Names, Type: g.qualify(f.Type)})
+	}
+
+	return r
+}
@@ -0,0 +1,398 @@
+// Command rpcgen generates the wrappers of node actions from the
+// declarative spec in actions.json: typed request and response
+// structs, Client methods, their documentation and the wrappers
+// over the default client.
+//
+// Every action of the spec gets a request and a response struct.
+// Actions marked handwritten keep their hand-written methods, for
+// responses that need more than picking fields.
+//
+// Spec entries look like this:
+//
+//	{
+//	  "action": "accounts_pending",
+//	  "method": "AccountsPending",
+//	  "doc": ["Returns a list of block hashes which have not", "yet been received by these accounts."],
+//	  "since": "8.0",
+//	  "control": false,
+//	  "params": ["accounts []string", "count int", "threshold? string"],
+//	  "fixed": {"type": "open"},
+//	  "response": ["blocks Map[interface{}]"],
+//	  "returns": ["blocks"]
+//	}
+//
+// Params and response fields are "name type" pairs, where the name is
+// the JSON key. Optional params, marked with "?", are left out of the
+// request when they hold their zero value. Fixed params are sent with
+// every request. Returns lists the response fields the method returns,
+// or "*" to return the whole response as a map of strings.
+//
+// Since is the node version the action needs, noted at the end of the
+// first sentence of its doc. Methods of responses holding a block
+// pointer under "block" and its hash under "hash", like those of
+// block_create, reject a null block and set the hash of the block.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type action struct {
+	Action      string            `json:"action"`
+	Method      string            `json:"method"`
+	Doc         []string          `json:"doc"`
+	Since       string            `json:"since"`
+	Control     bool              `json:"control"`
+	Handwritten bool              `json:"handwritten"`
+	Params      []string          `json:"params"`
+	Fixed       map[string]string `json:"fixed"`
+	Response    []string          `json:"response"`
+	Returns     []string          `json:"returns"`
+}
+
+type field struct {
+	key      string
+	typ      string
+	optional bool
+}
+
+func main() {
+	spec := flag.String("spec", "actions.json", "spec of the actions")
+	out := flag.String("o", "actions_gen.go", "output file for structs and methods")
+	defaults := flag.String("default", "default_gen.go", "output file for wrappers over the default client")
+	flag.Parse()
+
+	if err := run(*spec, *out, *defaults); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Generates out and defaults from the spec in file spec.
+func run(spec, out, defaults string) error {
+	raw, err := os.ReadFile(spec)
+	if err != nil {
+		return err
+	}
+
+	var actions []action
+	if err = json.Unmarshal(raw, &actions); err != nil {
+		return fmt.Errorf("%s: %v", spec, err)
+	}
+
+	var methods, wrappers bytes.Buffer
+	for _, a := range actions {
+		if err = generate(&methods, &wrappers, a); err != nil {
+			return fmt.Errorf("%s: %s: %v", spec, a.Action, err)
+		}
+	}
+
+	if err = write(out, spec, methods.Bytes()); err != nil {
+		return err
+	}
+
+	return write(defaults, spec, wrappers.Bytes())
+}
+
+func write(file, spec string, body []byte) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by rpcgen from %s. DO NOT EDIT.\n\npackage rpc\n\n", filepath.Base(spec))
+	var imports []string
+	for _, pkg := range []string{"context", "encoding/json", "fmt"} {
+		if bytes.Contains(body, []byte(path.Base(pkg)+".")) {
+			imports = append(imports, fmt.Sprintf("%q", pkg))
+		}
+	}
+	if len(imports) > 0 {
+		fmt.Fprintf(&buf, "import (\n%s\n)\n", strings.Join(imports, "\n"))
+	}
+	buf.Write(body)
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+
+	return os.WriteFile(file, code, 0644)
+}
+
+func parseFields(specs []string) ([]field, error) {
+	fields := make([]field, len(specs))
+	for i, s := range specs {
+		key, typ, ok := strings.Cut(s, " ")
+		if !ok {
+			return nil, fmt.Errorf("field %q has no type", s)
+		}
+
+		fields[i] = field{
+			key:      strings.TrimSuffix(key, "?"),
+			typ:      strings.TrimSpace(typ),
+			optional: strings.HasSuffix(key, "?"),
+		}
+	}
+
+	return fields, nil
+}
+
+func generate(w, defaults *bytes.Buffer, a action) error {
+	params, err := parseFields(a.Params)
+	if err != nil {
+		return err
+	}
+
+	response, err := parseFields(a.Response)
+	if err != nil {
+		return err
+	}
+
+	// Request struct.
+	fmt.Fprintf(w, "// %sRequest holds the params of %s, for use with Call.\n", a.Method, a.Action)
+	fmt.Fprintf(w, "type %sRequest struct {\n", a.Method)
+	for _, key := range sortedKeys(a.Fixed) {
+		fmt.Fprintf(w, "// Always %q.\n%s string `json:%q`\n", a.Fixed[key], goName(key), key)
+	}
+	for _, p := range params {
+		tag := p.key
+		if p.optional {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(w, "%s %s `json:%q`\n", goName(p.key), p.typ, tag)
+	}
+	fmt.Fprintf(w, "}\n\n")
+
+	// Response struct.
+	fmt.Fprintf(w, "// %sResponse holds the response of %s.\ntype %sResponse struct {\n", a.Method, a.Action, a.Method)
+	types := make(map[string]string, len(response))
+	for _, f := range response {
+		types[f.key] = f.typ
+		tag := f.key
+		if f.typ == "int" {
+			tag += ",string"
+		}
+		if f.optional {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(w, "%s %s `json:%q`\n", goName(f.key), f.typ, tag)
+	}
+	fmt.Fprintf(w, "}\n\n")
+
+	if a.Handwritten {
+		return nil
+	}
+
+	// Signature.
+	var args, names []string
+	for i, p := range params {
+		// Consecutive params of the same type share it.
+		if i+1 < len(params) && params[i+1].typ == p.typ {
+			args = append(args, goArg(p.key))
+		} else {
+			args = append(args, goArg(p.key)+" "+p.typ)
+		}
+		names = append(names, goArg(p.key))
+	}
+
+	whole := len(a.Returns) == 1 && a.Returns[0] == "*"
+
+	var results, zeros, values []string
+	var required []string
+	switch {
+	case whole:
+		results = append(results, "map[string]string")
+	default:
+		for _, key := range a.Returns {
+			typ, ok := types[key]
+			if !ok {
+				return fmt.Errorf("returns %s, which is not a response field", key)
+			}
+
+			results = append(results, returnType(typ))
+			zeros = append(zeros, zeroValue(returnType(typ)))
+			values = append(values, convert(typ, "r."+goName(key)))
+		}
+	}
+	for _, f := range response {
+		if !f.optional && f.typ != "Flag" {
+			required = append(required, fmt.Sprintf("%q", f.key))
+		}
+	}
+	results = append(results, "error")
+	zeros = append(zeros, "err")
+	values = append(values, "nil")
+
+	signature := fmt.Sprintf("(%s)", strings.Join(append([]string{"ctx context.Context"}, args...), ", "))
+	defaultSignature := fmt.Sprintf("(%s)", strings.Join(args, ", "))
+	resultList := results[0]
+	if len(results) > 1 {
+		resultList = "(" + strings.Join(results, ", ") + ")"
+	}
+
+	// Method.
+	for _, line := range doc(a) {
+		fmt.Fprintf(w, "// %s\n", line)
+	}
+	fmt.Fprintf(w, "func (c *Client) %s%s %s {\n", a.Method, signature, resultList)
+
+	payload := "nil"
+	if len(params) > 0 || len(a.Fixed) > 0 {
+		payload = "payload"
+		fmt.Fprintf(w, "payload := map[string]interface{}{\n")
+		for _, key := range sortedKeys(a.Fixed) {
+			fmt.Fprintf(w, "%q: %q,\n", key, a.Fixed[key])
+		}
+		for _, p := range params {
+			if !p.optional {
+				fmt.Fprintf(w, "%q: %s,\n", p.key, goArg(p.key))
+			}
+		}
+		fmt.Fprintf(w, "}\n\n")
+
+		for _, p := range params {
+			if p.optional {
+				fmt.Fprintf(w, "if %s {\npayload[%q] = %s\n}\n\n", nonZero(goArg(p.key), p.typ), p.key, goArg(p.key))
+			}
+		}
+	}
+
+	switch {
+	case whole:
+		fmt.Fprintf(w, "return c.fetchMap(ctx, %q, %s, \"\")\n}\n\n", a.Action, payload)
+	case len(a.Returns) == 0:
+		fmt.Fprintf(w, "_, err := c.call(ctx, %q, %s)\n\nreturn err\n}\n\n", a.Action, payload)
+	default:
+		fmt.Fprintf(w, "var r %sResponse\n", a.Method)
+		fmt.Fprintf(w, "if err := c.fetch(%s); err != nil {\nreturn %s\n}\n\n",
+			strings.Join(append([]string{"ctx", fmt.Sprintf("%q", a.Action), payload, "&r"}, required...), ", "),
+			strings.Join(zeros, ", "))
+		if strings.HasPrefix(types["block"], "*") && types["hash"] == "string" {
+			malformed := fmt.Sprintf("fmt.Errorf(\"%%w: response of %s has a null block\", ErrMalformedResponse)", a.Action)
+			fmt.Fprintf(w, "if r.Block == nil {\nreturn %s\n}\nr.Block.setHash(r.Hash)\n\n",
+				strings.Join(append(zeros[:len(zeros)-1:len(zeros)-1], malformed), ", "))
+		}
+		fmt.Fprintf(w, "return %s\n}\n\n", strings.Join(values, ", "))
+	}
+
+	// Wrapper over the default client.
+	fmt.Fprintf(defaults, "// Calls %s on the default client.\n", a.Method)
+	fmt.Fprintf(defaults, "func %s%s %s {\n", a.Method, defaultSignature, resultList)
+	fmt.Fprintf(defaults, "return client.%s(%s)\n}\n\n", a.Method, strings.Join(append([]string{"context.Background()"}, names...), ", "))
+
+	return nil
+}
+
+// Returns the doc comment of the method of a. The version it
+// requires ends its first sentence, the way params added later
+// are marked in the spec.
+func doc(a action) []string {
+	lines := append([]string(nil), a.Doc...)
+	for i, line := range lines {
+		if a.Since != "" && (strings.HasSuffix(line, ".") || i == len(lines)-1) {
+			lines[i] = fmt.Sprintf("%s (>= v%s).", strings.TrimSuffix(line, "."), a.Since)
+			break
+		}
+	}
+	if a.Control {
+		lines = append(lines, "Requires enable_control.")
+	}
+
+	return lines
+}
+
+// Returns the type a method returns for a response field of typ.
+func returnType(typ string) string {
+	switch {
+	case typ == "Flag":
+		return "bool"
+	case typ == "BlockMap":
+		return "map[string]Block"
+	case strings.HasPrefix(typ, "List[") && strings.HasSuffix(typ, "]"):
+		return "[]" + typ[len("List["):len(typ)-1]
+	case strings.HasPrefix(typ, "Map[") && strings.HasSuffix(typ, "]"):
+		return "map[string]" + typ[len("Map["):len(typ)-1]
+	default:
+		return typ
+	}
+}
+
+// Converts the response field v of typ to its return type.
+func convert(typ, v string) string {
+	if rt := returnType(typ); rt != typ {
+		return rt + "(" + v + ")"
+	}
+
+	return v
+}
+
+func zeroValue(typ string) string {
+	switch typ {
+	case "string":
+		return `""`
+	case "int", "uint64":
+		return "0"
+	case "bool":
+		return "false"
+	default:
+		return "nil"
+	}
+}
+
+func nonZero(v, typ string) string {
+	switch typ {
+	case "string":
+		return v + ` != ""`
+	case "int", "uint64":
+		return v + " > 0"
+	case "bool":
+		return v
+	default:
+		return "len(" + v + ") > 0"
+	}
+}
+
+// Words spelled in upper case in Go names.
+var initialisms = map[string]bool{"id": true, "json": true, "rpc": true, "url": true}
+
+// Returns the exported Go name of a JSON key, e.g. OpenBlock for open_block.
+func goName(key string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(key, "_") {
+		if initialisms[word] {
+			b.WriteString(strings.ToUpper(word))
+		} else if word != "" {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+
+	return b.String()
+}
+
+// Returns the name of the method argument for a JSON key.
+func goArg(key string) string {
+	name := goName(key)
+	for word := range initialisms {
+		if strings.HasPrefix(name, strings.ToUpper(word)) {
+			return word + name[len(word):]
+		}
+	}
+
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+// Generates from spec into a temporary directory and compares
+// the output with the files in dir, named after want.
+func check(t *testing.T, spec string, want map[string]string) {
+	t.Helper()
+
+	tmp := t.TempDir()
+	out := filepath.Join(tmp, "actions_gen.go")
+	defaults := filepath.Join(tmp, "default_gen.go")
+	if err := run(spec, out, defaults); err != nil {
+		t.Fatal(err)
+	}
+
+	for got, file := range map[string]string{out: want["actions"], defaults: want["default"]} {
+		generated, err := os.ReadFile(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if *update && filepath.Dir(file) == "testdata" {
+			if err = os.WriteFile(file, generated, 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+
+		expected, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(generated, expected) {
+			t.Errorf("%s differs from the output of rpcgen for %s", file, spec)
+		}
+	}
+}
+
+// Run with -update to accept changes of the output.
+func TestGolden(t *testing.T) {
+	check(t, "testdata/actions.json", map[string]string{
+		"actions": "testdata/actions_gen.go.golden",
+		"default": "testdata/default_gen.go.golden",
+	})
+}
+
+// Run go generate in rpc when this fails.
+func TestUpToDate(t *testing.T) {
+	check(t, "../../actions.json", map[string]string{
+		"actions": "../../actions_gen.go",
+		"default": "../../default_gen.go",
+	})
+}
@@ -0,0 +1,120 @@
+package rpc
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+)
+
+// Preamble sent ahead of every IPC request: the magic 'N', the payload
+// encoding and two reserved bytes. Encoding 1 carries the same JSON
+// payloads as the HTTP RPC server, prefixed by a big endian uint32 length.
+var ipcPreamble = [4]byte{'N', 1, 0, 0}
+
+// Largest IPC response accepted from the node,
+// unless set with WithMaxResponseSize.
+const maxIPCResponse = 256 << 20
+
+// Talks to the node's IPC server over a unix domain socket.
+// Selected by NewClient for unix:// URLs, e.g. unix:///var/run/nano.sock.
+type ipcTransport struct {
+	path        string
+	maxResponse int64
+}
+
+func (t *ipcTransport) RoundTrip(ctx context.Context, action string, body []byte) ([]byte, error) {
+	var raw []byte
+	err := t.Stream(ctx, action, body, func(r io.Reader) (err error) {
+		raw, err = io.ReadAll(r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return raw, nil
+}
+
+func (t *ipcTransport) Stream(ctx context.Context, action string, body []byte, fn func(io.Reader) error) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unix", t.path)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	// Unblock reads and writes once ctx is canceled.
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	msg := make([]byte, 0, len(ipcPreamble)+4+len(body))
+	msg = append(msg, ipcPreamble[:]...)
+	msg = binary.BigEndian.AppendUint32(msg, uint32(len(body)))
+	msg = append(msg, body...)
+
+	if _, err = conn.Write(msg); err != nil {
+		return ipcError(ctx, err)
+	}
+
+	var size [4]byte
+	if _, err = io.ReadFull(conn, size[:]); err != nil {
+		return ipcError(ctx, err)
+	}
+
+	max := t.maxResponse
+	if max <= 0 {
+		max = maxIPCResponse
+	}
+
+	n := int64(binary.BigEndian.Uint32(size[:]))
+	if n > max {
+		return tooLarge(max)
+	}
+
+	if err = fn(&ipcResponse{r: conn, left: n}); err != nil {
+		return ipcError(ctx, err)
+	}
+
+	return nil
+}
+
+// Reads the response of a known length, failing
+// with io.ErrUnexpectedEOF if the node sends less.
+type ipcResponse struct {
+	r    io.Reader
+	left int64
+}
+
+func (r *ipcResponse) Read(p []byte) (int, error) {
+	if r.left <= 0 {
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > r.left {
+		p = p[:r.left]
+	}
+
+	n, err := r.r.Read(p)
+	r.left -= int64(n)
+	if err == io.EOF && r.left > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return n, err
+}
+
+// Prefers the context error over the one caused by closing the connection.
+func ipcError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return err
+}
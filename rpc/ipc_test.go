@@ -0,0 +1,162 @@
+package rpc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+// Listens on a unix socket, handling every connection with handle.
+// Returns the unix:// URL of the socket.
+func ipcServer(t *testing.T, handle func(net.Conn)) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "node.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				handle(conn)
+			}()
+		}
+	}()
+
+	return "unix://" + path
+}
+
+// Reads a framed IPC request, checking its preamble.
+func readIPCRequest(t *testing.T, conn net.Conn) []byte {
+	t.Helper()
+
+	var header [8]byte
+	if _, err := io.ReadFull(conn, header[:]); err != nil {
+		t.Error(err)
+		return nil
+	}
+
+	if !bytes.Equal(header[:4], []byte{'N', 1, 0, 0}) {
+		t.Errorf("preamble %v", header[:4])
+	}
+
+	body := make([]byte, binary.BigEndian.Uint32(header[4:]))
+	if _, err := io.ReadFull(conn, body); err != nil {
+		t.Error(err)
+		return nil
+	}
+
+	return body
+}
+
+// Serves the requests of the IPC connection with the fake node n.
+func serveIPC(t *testing.T, n *rpctest.Node) func(net.Conn) {
+	return func(conn net.Conn) {
+		body := readIPCRequest(t, conn)
+		if body == nil {
+			return
+		}
+
+		w := httptest.NewRecorder()
+		n.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+
+		resp := binary.BigEndian.AppendUint32(nil, uint32(w.Body.Len()))
+		conn.Write(append(resp, w.Body.Bytes()...))
+	}
+}
+
+func TestIPC(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	c := rpc.NewClient(ipcServer(t, serveIPC(t, n)))
+	ctx := context.Background()
+
+	wallet, err := c.CreateWallet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := c.CreateAccount(ctx, wallet, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts, err := c.AccountList(ctx, wallet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 1 || accounts[0] != account {
+		t.Errorf("accounts of the wallet = %v, want %s", accounts, account)
+	}
+
+	// Error envelopes come back as node errors.
+	if _, err = c.AccountList(ctx, "0"); !errors.Is(err, rpc.ErrBadWallet) {
+		t.Errorf("bad wallet returned %v, want ErrBadWallet", err)
+	}
+}
+
+func TestIPCResponseSize(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	c := rpc.NewClient(ipcServer(t, serveIPC(t, n)), rpc.WithMaxResponseSize(16))
+
+	if _, err := c.BlockCount(context.Background()); !errors.Is(err, rpc.ErrResponseTooLarge) {
+		t.Errorf("oversized response returned %v, want ErrResponseTooLarge", err)
+	}
+}
+
+func TestIPCShortResponse(t *testing.T) {
+	c := rpc.NewClient(ipcServer(t, func(conn net.Conn) {
+		readIPCRequest(t, conn)
+
+		// Announces more than it sends.
+		resp := binary.BigEndian.AppendUint32(nil, 64)
+		conn.Write(append(resp, `{"count": "1"}`...))
+	}))
+
+	if _, err := c.BlockCount(context.Background()); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("truncated response returned %v, want ErrUnexpectedEOF", err)
+	}
+}
+
+func TestIPCCancel(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+
+	c := rpc.NewClient(ipcServer(t, func(conn net.Conn) {
+		readIPCRequest(t, conn)
+		<-done
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	if _, err := c.BlockCount(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled call returned %v, want context.Canceled", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("canceled call took %v", d)
+	}
+}
@@ -0,0 +1,338 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"sort"
+	"strings"
+)
+
+// Lowest account number, used as the start of walks over all accounts.
+const burnAccount = "xrb_1111111111111111111111111111111111111111111111111111hifc8npp"
+
+// Default number of entries requested per page.
+const defaultPageSize = 1000
+
+// WalkOptions controls where a walk starts and how much it requests at once.
+type WalkOptions struct {
+	// Account, key or block hash to start at. Defaults to the
+	// start of the ledger, or the frontier for account history.
+	Start string
+	// Entries requested per page. Defaults to 1000.
+	PageSize int
+}
+
+func (o WalkOptions) pageSize() int {
+	switch {
+	case o.PageSize <= 0:
+		return defaultPageSize
+	case o.PageSize < 2:
+		// The start of a page repeats the end of the previous one,
+		// so pages of one entry would never make progress.
+		return 2
+	default:
+		return o.PageSize
+	}
+}
+
+func (o WalkOptions) startAccount() string {
+	if o.Start == "" {
+		return burnAccount
+	}
+
+	return o.Start
+}
+
+// LedgerOptions controls a walk over the ledger.
+type LedgerOptions struct {
+	WalkOptions
+	Representative bool
+	Weight         bool
+	Pending        bool
+}
+
+// LedgerEntry is an account of the ledger.
+type LedgerEntry struct {
+	Account string
+	Info    *Account
+}
+
+// Frontier is the head block of an account.
+type Frontier struct {
+	Account string
+	Hash    string
+}
+
+// UncheckedEntry is a block waiting for a dependency, keyed
+// by the hash or account it waits for.
+type UncheckedEntry struct {
+	Key      string
+	Hash     string
+	Contents Block
+}
+
+func (e *UncheckedEntry) UnmarshalJSON(data []byte) error {
+	var r struct {
+		Key      string   `json:"key"`
+		Hash     string   `json:"hash"`
+		Contents anyBlock `json:"contents"`
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+
+	if r.Contents.Block != nil {
+		r.Contents.setHash(r.Hash)
+	}
+	*e = UncheckedEntry{Key: r.Key, Hash: r.Hash, Contents: r.Contents.Block}
+
+	return nil
+}
+
+// HistoryEntry is a send or receive of an account.
+type HistoryEntry struct {
+	Type    string `json:"type"`
+	Account string `json:"account"`
+	Amount  string `json:"amount"`
+	Hash    string `json:"hash"`
+}
+
+// Walks every account of the ledger in account order, paging
+// through it with Ledger. Iteration stops at the first error,
+// which is yielded, or when ctx is done.
+// Requires enable_control.
+func (c *Client) LedgerAll(ctx context.Context, opts LedgerOptions) iter.Seq2[LedgerEntry, error] {
+	return walkAccounts(ctx, opts.WalkOptions, func(ctx context.Context, start string, count int) (map[string]*Account, error) {
+		payload := map[string]interface{}{
+			"account":        start,
+			"count":          count,
+			"representative": opts.Representative,
+			"weight":         opts.Weight,
+			"pending":        opts.Pending,
+		}
+
+		raw, err := c.call(ctx, "ledger", payload)
+		if err != nil {
+			return nil, err
+		}
+
+		var r struct {
+			Accounts map[string]*Account `json:"accounts"`
+		}
+		if err = json.Unmarshal(raw, &r); err != nil {
+			return nil, malformed("ledger", err)
+		}
+
+		if r.Accounts == nil {
+			return nil, errMissingKey("ledger", "accounts")
+		}
+
+		return r.Accounts, nil
+	}, func(account string, info *Account) LedgerEntry {
+		return LedgerEntry{Account: account, Info: info}
+	})
+}
+
+// Walks the frontier of every account in account order,
+// paging through them with Frontiers. Iteration stops at the
+// first error, which is yielded, or when ctx is done.
+func (c *Client) FrontiersAll(ctx context.Context, opts WalkOptions) iter.Seq2[Frontier, error] {
+	return walkAccounts(ctx, opts, c.Frontiers, func(account, hash string) Frontier {
+		return Frontier{Account: account, Hash: hash}
+	})
+}
+
+// Walks the unchecked blocks in key order, paging through them
+// with unchecked_keys. Iteration stops at the first error,
+// which is yielded, or when ctx is done.
+// Requires enable_control.
+func (c *Client) UncheckedKeysAll(ctx context.Context, opts WalkOptions) iter.Seq2[UncheckedEntry, error] {
+	return func(yield func(UncheckedEntry, error) bool) {
+		key := opts.Start
+		if key == "" {
+			key = strings.Repeat("0", 64)
+		}
+		size := opts.pageSize()
+
+		// Entries of the last key of a page, which the next page repeats.
+		seen := make(map[string]bool)
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(UncheckedEntry{}, err)
+				return
+			}
+
+			payload := map[string]interface{}{
+				"key":   key,
+				"count": size,
+			}
+
+			raw, err := c.call(ctx, "unchecked_keys", payload)
+			if err != nil {
+				yield(UncheckedEntry{}, err)
+				return
+			}
+
+			var r struct {
+				Unchecked json.RawMessage `json:"unchecked"`
+			}
+			if err = json.Unmarshal(raw, &r); err != nil {
+				yield(UncheckedEntry{}, malformed("unchecked_keys", err))
+				return
+			}
+
+			if r.Unchecked == nil {
+				yield(UncheckedEntry{}, errMissingKey("unchecked_keys", "unchecked"))
+				return
+			}
+
+			var entries []UncheckedEntry
+			if err = decodeList(r.Unchecked, &entries); err != nil {
+				yield(UncheckedEntry{}, malformed("unchecked_keys", err))
+				return
+			}
+
+			fresh := 0
+			for _, e := range entries {
+				if strings.EqualFold(e.Key, key) && seen[e.Hash] {
+					continue
+				}
+
+				fresh++
+				if !yield(e, nil) {
+					return
+				}
+			}
+
+			if len(entries) < size {
+				return
+			}
+
+			last := entries[len(entries)-1].Key
+			if fresh == 0 {
+				// A single key fills the whole page, so
+				// ask for more entries at once instead.
+				size *= 2
+				continue
+			}
+
+			if !strings.EqualFold(last, key) {
+				clear(seen)
+				key = last
+			}
+			for _, e := range entries {
+				if strings.EqualFold(e.Key, key) {
+					seen[e.Hash] = true
+				}
+			}
+		}
+	}
+}
+
+// Walks the history of account from the newest block back to the
+// open block, or from the block opts.Start, paging through it with
+// account_history. Iteration stops at the first error, which is
+// yielded, or when ctx is done.
+func (c *Client) AccountHistoryAll(ctx context.Context, account string, opts WalkOptions) iter.Seq2[HistoryEntry, error] {
+	return func(yield func(HistoryEntry, error) bool) {
+		head := opts.Start
+		size := opts.pageSize()
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(HistoryEntry{}, err)
+				return
+			}
+
+			var entries []HistoryEntry
+			previous, err := c.accountHistory(ctx, account, head, size, &entries)
+			if err != nil {
+				yield(HistoryEntry{}, err)
+				return
+			}
+
+			for _, e := range entries {
+				if !yield(e, nil) {
+					return
+				}
+			}
+
+			// The node only returns previous when
+			// there are more blocks to read.
+			if previous == "" || previous == head {
+				return
+			}
+			head = previous
+		}
+	}
+}
+
+// Walks pages of an account keyed action. Pages start at the last
+// account of the previous page, which is skipped the second time.
+func walkAccounts[V, E any](ctx context.Context, opts WalkOptions, page func(context.Context, string, int) (map[string]V, error), entry func(string, V) E) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		var zero E
+
+		start := opts.startAccount()
+		size := opts.pageSize()
+
+		for first := true; ; first = false {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			values, err := page(ctx, start, size)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			accounts := sortAccounts(values)
+			for _, account := range accounts {
+				if !first && account == start {
+					continue
+				}
+
+				if !yield(entry(account, values[account]), nil) {
+					return
+				}
+			}
+
+			if len(accounts) < size {
+				return
+			}
+
+			last := accounts[len(accounts)-1]
+			if last == start {
+				return
+			}
+			start = last
+		}
+	}
+}
+
+// Returns the accounts of m in the order of their public keys.
+// The account encoding preserves the order of the keys, so
+// comparing the numbers without their prefix is enough.
+func sortAccounts[V any](m map[string]V) []string {
+	accounts := make([]string, 0, len(m))
+	for account := range m {
+		accounts = append(accounts, account)
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return stripPrefix(accounts[i]) < stripPrefix(accounts[j])
+	})
+
+	return accounts
+}
+
+func stripPrefix(account string) string {
+	if i := strings.IndexByte(account, '_'); i >= 0 {
+		return account[i+1:]
+	}
+
+	return account
+}
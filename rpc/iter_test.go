@@ -0,0 +1,267 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+// Opens count accounts of a new wallet.
+func openAccounts(t *testing.T, n *rpctest.Node, c *rpc.Client, count int) []string {
+	t.Helper()
+	ctx := context.Background()
+
+	wallet, err := c.CreateWallet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts := make([]string, count)
+	for i := range accounts {
+		if accounts[i], err = c.CreateAccount(ctx, wallet, false); err != nil {
+			t.Fatal(err)
+		}
+
+		hash, err := n.Fund(accounts[i], "10")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = c.ReceiveBlock(ctx, wallet, accounts[i], hash, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return accounts
+}
+
+// Pages repeat the last account of the previous one,
+// which the walks yield only once.
+func TestWalkAccounts(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+
+	openAccounts(t, n, c, 6)
+
+	all, err := c.Frontiers(ctx, firstAccount, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, size := range []int{1, 2, 3, 7, 100} {
+		opts := rpc.WalkOptions{PageSize: size}
+
+		var frontiers []string
+		for f, err := range c.FrontiersAll(ctx, opts) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if all[f.Account] != f.Hash {
+				t.Errorf("page size %d: frontier of %s is %s, want %s", size, f.Account, f.Hash, all[f.Account])
+			}
+			frontiers = append(frontiers, f.Account)
+		}
+
+		var ledger []string
+		for e, err := range c.LedgerAll(ctx, rpc.LedgerOptions{WalkOptions: opts}) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.Info == nil || e.Info.Frontier != all[e.Account] {
+				t.Errorf("page size %d: ledger entry %+v", size, e)
+			}
+			ledger = append(ledger, e.Account)
+		}
+
+		for name, accounts := range map[string][]string{"frontiers": frontiers, "ledger": ledger} {
+			if len(accounts) != len(all) || !unique(accounts) {
+				t.Errorf("page size %d: %s walked %d accounts %v, want each of %d once", size, name, len(accounts), accounts, len(all))
+			}
+		}
+	}
+
+	if n.Requests("frontiers") < 2 || n.Requests("ledger") < 2 {
+		t.Errorf("walks made %d frontiers and %d ledger requests", n.Requests("frontiers"), n.Requests("ledger"))
+	}
+}
+
+func TestWalkAccountsStart(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+
+	accounts := openAccounts(t, n, c, 4)
+
+	var frontiers []string
+	for f, err := range c.FrontiersAll(ctx, rpc.WalkOptions{}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		frontiers = append(frontiers, f.Account)
+	}
+	if len(frontiers) != len(accounts)+1 {
+		t.Fatalf("walked %v", frontiers)
+	}
+
+	// Walks starting at an account include it.
+	var rest []string
+	for f, err := range c.FrontiersAll(ctx, rpc.WalkOptions{Start: frontiers[2], PageSize: 2}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		rest = append(rest, f.Account)
+	}
+	if len(rest) != len(frontiers)-2 || rest[0] != frontiers[2] || !unique(rest) {
+		t.Errorf("walk from %s returned %v, want %v", frontiers[2], rest, frontiers[2:])
+	}
+
+	// Breaking out of the loop ends the walk.
+	var seen int
+	for range c.FrontiersAll(ctx, rpc.WalkOptions{PageSize: 2}) {
+		seen++
+		break
+	}
+	if seen != 1 {
+		t.Errorf("yielded %d entries after the loop ended", seen)
+	}
+}
+
+// Keys can fill whole pages, which the walk neither
+// repeats nor gets stuck on.
+func TestUncheckedKeysAll(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+
+	wallet, account := fundedWallet(t, n, c, "1000")
+
+	// Missing previous blocks and the number of blocks waiting for each.
+	keys := []struct {
+		hash   string
+		blocks int
+	}{
+		{"A000000000000000000000000000000000000000000000000000000000000001", 5},
+		{"B000000000000000000000000000000000000000000000000000000000000001", 1},
+		{"C000000000000000000000000000000000000000000000000000000000000001", 3},
+	}
+
+	want := make(map[string]string)
+	for _, key := range keys {
+		for i := 0; i < key.blocks; i++ {
+			hash, block, err := c.CreateStateBlock(ctx, rpc.SubtypeSend, rpc.CreateStateBlockRequest{
+				Wallet:         wallet,
+				Account:        account,
+				Previous:       key.hash,
+				Representative: account,
+				Balance:        strconv.Itoa(i + 1),
+				Link:           n.Genesis(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.ProcessBlock(ctx, block, "", false, false)
+			want[hash] = key.hash
+		}
+	}
+
+	for _, size := range []int{1, 2, 3, 4, 100} {
+		var hashes []string
+		for e, err := range c.UncheckedKeysAll(ctx, rpc.WalkOptions{PageSize: size}) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want[e.Hash] != e.Key || e.Contents == nil || e.Contents.Hash() != e.Hash {
+				t.Errorf("page size %d: unchecked entry %+v", size, e)
+			}
+			hashes = append(hashes, e.Hash)
+		}
+
+		if len(hashes) != len(want) || !unique(hashes) {
+			t.Errorf("page size %d: walked %d blocks, want each of %d once", size, len(hashes), len(want))
+		}
+	}
+
+	// Walks starting at a key skip the blocks before it.
+	var count int
+	for _, err := range c.UncheckedKeysAll(ctx, rpc.WalkOptions{Start: keys[1].hash, PageSize: 2}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		count++
+	}
+	if count != keys[1].blocks+keys[2].blocks {
+		t.Errorf("walk from %s yielded %d blocks", keys[1].hash, count)
+	}
+}
+
+// Walks and account history fail on malformed pages
+// like every other call.
+func TestWalkMalformed(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+
+	calls := map[string]func() error{
+		"LedgerAll": func() error {
+			return firstErr(c.LedgerAll(ctx, rpc.LedgerOptions{}))
+		},
+		"UncheckedKeysAll": func() error {
+			return firstErr(c.UncheckedKeysAll(ctx, rpc.WalkOptions{}))
+		},
+		"AccountHistoryAll": func() error {
+			return firstErr(c.AccountHistoryAll(ctx, n.Genesis(), rpc.WalkOptions{}))
+		},
+		"AccountHistory": func() error {
+			_, err := c.AccountHistory(ctx, n.Genesis(), 10)
+			return err
+		},
+	}
+
+	for _, body := range []string{
+		`[]`,
+		`{"accounts": [], "unchecked": 1, "history": 1}`,
+		`{"accounts": {"nano_a": 1}, "unchecked": [1], "history": [1]}`,
+	} {
+		n.OnRequest(reply(body))
+
+		for name, call := range calls {
+			if err := call(); !errors.Is(err, rpc.ErrMalformedResponse) {
+				t.Errorf("%s of %s returned %v, want ErrMalformedResponse", name, body, err)
+			}
+		}
+	}
+}
+
+// Returns the first error yielded by seq.
+func firstErr[T any](seq iter.Seq2[T, error]) error {
+	for _, err := range seq {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reports whether s holds no duplicates.
+func unique(s []string) bool {
+	sorted := append([]string(nil), s...)
+	sort.Strings(sorted)
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return false
+		}
+	}
+
+	return true
+}
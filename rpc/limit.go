@@ -0,0 +1,234 @@
+package rpc
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Suggested weights for expensive actions, for use with WithActionWeights.
+var DefaultActionWeights = map[string]int{
+	"work_generate":       8,
+	"ledger":              4,
+	"unchecked":           4,
+	"unchecked_keys":      4,
+	"frontiers":           2,
+	"accounts_balances":   2,
+	"accounts_pending":    2,
+	"accounts_receivable": 2,
+	"blocks_info":         2,
+	"delegators":          2,
+	"wallet_balances":     2,
+	"wallet_pending":      2,
+	"wallet_receivable":   2,
+}
+
+// Limits the rate of requests to rps per second, allowing bursts of
+// up to burst requests. Waiting honours the context of the call.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) {
+		if rps <= 0 {
+			return
+		}
+
+		if burst < 1 {
+			burst = 1
+		}
+
+		c.limiter().bucket = &tokenBucket{
+			rate:   rps,
+			burst:  float64(burst),
+			tokens: float64(burst),
+			last:   time.Now(),
+		}
+	}
+}
+
+// Caps the number of requests in flight at n.
+func WithMaxInFlight(n int) Option {
+	return func(c *Client) {
+		if n < 1 {
+			n = 1
+		}
+
+		c.limiter().sem = &semaphore{size: n}
+	}
+}
+
+// Makes actions cost weights[action] tokens and in-flight slots instead
+// of one. Weights above the burst or in-flight cap are clamped to it.
+func WithActionWeights(weights map[string]int) Option {
+	return func(c *Client) {
+		l := c.limiter()
+		l.weights = make(map[string]int, len(weights))
+		for action, w := range weights {
+			l.weights[action] = w
+		}
+	}
+}
+
+// Returns the limiter of c, creating it if needed.
+func (c *Client) limiter() *limiter {
+	if c.limits == nil {
+		c.limits = &limiter{}
+	}
+
+	return c.limits
+}
+
+type limiter struct {
+	bucket  *tokenBucket
+	sem     *semaphore
+	weights map[string]int
+}
+
+// Waits until action may be sent. The returned function
+// must be called once the request is done.
+func (l *limiter) acquire(ctx context.Context, action string) (func(), error) {
+	w := 1
+	if weight, ok := l.weights[action]; ok && weight > 0 {
+		w = weight
+	}
+
+	if l.bucket != nil {
+		if err := l.bucket.wait(ctx, w); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.sem == nil {
+		return func() {}, nil
+	}
+
+	n, err := l.sem.acquire(ctx, w)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() { l.sem.release(n) }, nil
+}
+
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// Takes n tokens, waiting for them to refill if needed.
+func (b *tokenBucket) wait(ctx context.Context, n int) error {
+	want := float64(n)
+	if want > b.burst {
+		want = b.burst
+	}
+
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+
+		if b.tokens >= want {
+			b.tokens -= want
+			b.mu.Unlock()
+			return nil
+		}
+
+		d := time.Duration((want - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		if err := sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// Weighted semaphore granting slots in the order they were asked for,
+// so heavy requests aren't starved by a stream of light ones.
+type semaphore struct {
+	size int
+
+	mu      sync.Mutex
+	cur     int
+	waiters list.List
+}
+
+type waiter struct {
+	n     int
+	ready chan struct{}
+}
+
+// Acquires n slots, clamped to the size of the semaphore.
+// Returns the number of slots actually acquired.
+func (s *semaphore) acquire(ctx context.Context, n int) (int, error) {
+	if n > s.size {
+		n = s.size
+	}
+
+	s.mu.Lock()
+	if s.cur+n <= s.size && s.waiters.Len() == 0 {
+		s.cur += n
+		s.mu.Unlock()
+		return n, nil
+	}
+
+	ready := make(chan struct{})
+	elem := s.waiters.PushBack(waiter{n: n, ready: ready})
+	s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-ready:
+			// Granted while giving up, hand the slots back.
+			s.cur -= n
+			s.notify()
+		default:
+			front := s.waiters.Front() == elem
+			s.waiters.Remove(elem)
+			// Waiters queued behind the front one may fit now.
+			if front {
+				s.notify()
+			}
+		}
+		s.mu.Unlock()
+
+		return 0, ctx.Err()
+	case <-ready:
+		return n, nil
+	}
+}
+
+func (s *semaphore) release(n int) {
+	s.mu.Lock()
+	s.cur -= n
+	s.notify()
+	s.mu.Unlock()
+}
+
+// Grants slots to waiters in order, as long as the first one fits.
+// Must be called with s.mu held.
+func (s *semaphore) notify() {
+	for {
+		front := s.waiters.Front()
+		if front == nil {
+			return
+		}
+
+		w := front.Value.(waiter)
+		if s.cur+w.n > s.size {
+			return
+		}
+
+		s.cur += w.n
+		s.waiters.Remove(front)
+		close(w.ready)
+	}
+}
@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// Waits until n requests are queued on s.
+func waitQueued(t *testing.T, s *semaphore, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		s.mu.Lock()
+		queued := s.waiters.Len()
+		s.mu.Unlock()
+
+		if queued == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d requests queued, want %d", queued, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSemaphoreFIFO(t *testing.T) {
+	s := &semaphore{size: 8}
+	ctx := context.Background()
+
+	if _, err := s.acquire(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	heavy := make(chan int, 1)
+	go func() {
+		n, _ := s.acquire(ctx, 8)
+		heavy <- n
+	}()
+	waitQueued(t, s, 1)
+
+	// Fits, but must queue behind the heavy request.
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := s.acquire(short, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("light request overtook the heavy one: %v", err)
+	}
+
+	light := make(chan int, 1)
+	go func() {
+		n, _ := s.acquire(ctx, 1)
+		light <- n
+	}()
+	waitQueued(t, s, 2)
+
+	s.release(1)
+	if n := <-heavy; n != 8 {
+		t.Fatalf("heavy request acquired %d slots, want 8", n)
+	}
+
+	select {
+	case <-light:
+		t.Fatal("light request acquired a slot while the heavy one holds all")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	s.release(8)
+	if n := <-light; n != 1 {
+		t.Errorf("light request acquired %d slots, want 1", n)
+	}
+}
+
+func TestSemaphoreCancel(t *testing.T) {
+	s := &semaphore{size: 2}
+	ctx := context.Background()
+
+	if n, err := s.acquire(ctx, 5); err != nil || n != 2 {
+		t.Fatalf("acquire of more than the size = %d, %v, want it clamped to 2", n, err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	errs := make(chan error, 1)
+	go func() {
+		_, err := s.acquire(canceled, 2)
+		errs <- err
+	}()
+	waitQueued(t, s, 1)
+
+	light := make(chan int, 1)
+	go func() {
+		n, _ := s.acquire(ctx, 1)
+		light <- n
+	}()
+	waitQueued(t, s, 2)
+
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled acquire returned %v", err)
+	}
+
+	// The canceled request leaves the queue without taking slots.
+	s.release(2)
+	if n := <-light; n != 1 {
+		t.Fatalf("light request acquired %d slots, want 1", n)
+	}
+
+	s.mu.Lock()
+	cur, queued := s.cur, s.waiters.Len()
+	s.mu.Unlock()
+	if cur != 1 || queued != 0 {
+		t.Errorf("%d slots taken and %d requests queued, want 1 and 0", cur, queued)
+	}
+}
@@ -0,0 +1,186 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+// Returns a hook holding every request until release is closed,
+// the release channel and a func reporting the most weight held at
+// once, weighing actions with weights.
+func gate(weights map[string]int) (rpctest.Hook, chan struct{}, func() int) {
+	release := make(chan struct{})
+
+	var mu sync.Mutex
+	var cur, peak int
+
+	hook := func(w http.ResponseWriter, r *http.Request, action string) bool {
+		weight := 1
+		if w, ok := weights[action]; ok {
+			weight = w
+		}
+
+		mu.Lock()
+		cur += weight
+		if cur > peak {
+			peak = cur
+		}
+		mu.Unlock()
+
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+
+		mu.Lock()
+		cur -= weight
+		mu.Unlock()
+
+		return false
+	}
+
+	return hook, release, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return peak
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	c := n.Client(rpc.WithRateLimit(50, 3))
+	ctx := context.Background()
+
+	// The burst goes out at once, the rest at 50 per second.
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := c.BlockCount(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d > 15*time.Millisecond {
+		t.Errorf("burst took %v", d)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.BlockCount(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d < 50*time.Millisecond {
+		t.Errorf("6 calls with a burst of 3 at 50 per second took %v, want at least 60ms", d)
+	}
+}
+
+func TestRateLimitWeights(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	c := n.Client(rpc.WithRateLimit(50, 4), rpc.WithActionWeights(map[string]int{"ledger": 4}))
+	ctx := context.Background()
+
+	// A ledger call empties the bucket, the next call waits for a token.
+	c.Call(ctx, "ledger", nil, nil)
+
+	start := time.Now()
+	if _, err := c.BlockCount(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d < 15*time.Millisecond {
+		t.Errorf("call after a weighted one waited %v, want about 20ms", d)
+	}
+}
+
+func TestRateLimitCancel(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	c := n.Client(rpc.WithRateLimit(1, 1))
+	if _, err := c.BlockCount(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := c.BlockCount(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("call waiting for a token returned %v, want DeadlineExceeded", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("canceled wait took %v", d)
+	}
+}
+
+func TestMaxInFlight(t *testing.T) {
+	weights := map[string]int{"work_generate": 8}
+	n := rpctest.NewNode()
+	defer n.Close()
+	hook, release, peak := gate(weights)
+	n.OnRequest(hook)
+
+	c := n.Client(rpc.WithMaxInFlight(8), rpc.WithActionWeights(weights))
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for _, action := range []string{"block_count", "work_generate", "block_count", "version", "work_generate"} {
+		wg.Add(1)
+		go func(action string) {
+			defer wg.Done()
+			c.Call(ctx, action, nil, nil)
+		}(action)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if p := peak(); p > 8 {
+		t.Errorf("node held a weight of %d at once, want at most 8", p)
+	}
+}
+
+func TestMaxInFlightCancel(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	hook, release, _ := gate(nil)
+	n.OnRequest(hook)
+
+	c := n.Client(rpc.WithMaxInFlight(1))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.BlockCount(context.Background())
+		done <- err
+	}()
+
+	// Waits for a slot until its context ends.
+	time.Sleep(10 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.BlockCount(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("call waiting for a slot returned %v, want DeadlineExceeded", err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	// The slot of the canceled call wasn't leaked.
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := c.BlockCount(ctx); err != nil {
+		t.Errorf("call after the canceled one returned %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// Fields never written to logs, whether sent or received:
+// private keys, seeds and wallet passwords.
+var sensitiveFields = map[string]bool{
+	"key":      true,
+	"seed":     true,
+	"password": true,
+	"private":  true,
+}
+
+// Responses are only logged in full at debug level and below this size.
+const maxLoggedResponse = 4096
+
+// Logs every RPC call to l, with secrets redacted.
+func WithLogger(l *slog.Logger) Option {
+	return WithInterceptors(LoggingInterceptor(l))
+}
+
+// Returns an interceptor logging the action, latency, payload and
+// response size of every call. Successful calls are logged at debug
+// level together with small responses, failed calls at warn level.
+// Keys, seeds, passwords and the wallet_export JSON are redacted.
+func LoggingInterceptor(l *slog.Logger) Interceptor {
+	return func(ctx context.Context, action string, payload map[string]interface{}, next Invoker) ([]byte, error) {
+		start := time.Now()
+		raw, err := next(ctx, action, payload)
+
+		level := slog.LevelDebug
+		if err != nil {
+			level = slog.LevelWarn
+		}
+
+		if !l.Enabled(ctx, level) {
+			return raw, err
+		}
+
+		attrs := []slog.Attr{
+			slog.String("action", action),
+			slog.Duration("latency", time.Since(start)),
+			slog.Any("payload", Redact(action, payload)),
+			slog.Int("response_bytes", len(raw)),
+		}
+
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+
+		if len(raw) > 0 && len(raw) <= maxLoggedResponse && level == slog.LevelDebug {
+			var response interface{}
+			if json.Unmarshal(raw, &response) == nil {
+				attrs = append(attrs, slog.Any("response", Redact(action, response)))
+			}
+		}
+
+		l.LogAttrs(ctx, level, "rpc call", attrs...)
+
+		return raw, err
+	}
+}
+
+// Returns a copy of v, a payload or decoded response of action, with
+// keys, seeds, passwords and the wallet_export JSON replaced.
+func Redact(action string, v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		r := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			switch {
+			case sensitiveFields[k]:
+				r[k] = redacted
+			case k == "json" && action == "wallet_export":
+				r[k] = redacted
+			default:
+				r[k] = Redact(action, item)
+			}
+		}
+		return r
+	case []interface{}:
+		r := make([]interface{}, len(val))
+		for i, item := range val {
+			r[i] = Redact(action, item)
+		}
+		return r
+	default:
+		return v
+	}
+}
@@ -0,0 +1,103 @@
+package rpc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+func TestLoggingRedaction(t *testing.T) {
+	const seed = "5EED000000000000000000000000000000000000000000000000000000005EED"
+
+	n := rpctest.NewNode()
+	defer n.Close()
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	c := n.Client(rpc.WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+
+	wallet, err := c.CreateWallet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.ChangeWalletSeed(ctx, wallet, seed); err != nil {
+		t.Fatal(err)
+	}
+	exported, err := c.ExportWallet(ctx, wallet)
+	if err != nil || !strings.Contains(exported, seed) {
+		t.Fatalf("exported wallet %q, %v", exported, err)
+	}
+	key, err := c.KeyCreate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Passwords of failed calls are redacted too.
+	c.EnterWalletPassword(ctx, "0", "hunter2")
+
+	out := buf.String()
+	for _, secret := range []string{seed, key["private"], "hunter2"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log contains the secret %s:\n%s", secret, out)
+		}
+	}
+
+	var levels []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var entry struct {
+			Level  string `json:"level"`
+			Action string `json:"action"`
+		}
+		if err = json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		levels = append(levels, entry.Action+" "+entry.Level)
+	}
+
+	want := []string{"wallet_create DEBUG", "wallet_change_seed DEBUG", "wallet_export DEBUG", "key_create DEBUG", "password_enter WARN"}
+	if !reflect.DeepEqual(levels, want) {
+		t.Errorf("logged %v, want %v", levels, want)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		action string
+		in     interface{}
+		want   interface{}
+	}{
+		{"password_enter",
+			map[string]interface{}{"wallet": "W", "password": "p"},
+			map[string]interface{}{"wallet": "W", "password": "[REDACTED]"}},
+		{"wallet_export",
+			map[string]interface{}{"json": "{}"},
+			map[string]interface{}{"json": "[REDACTED]"}},
+		{"block",
+			map[string]interface{}{"json": "{}"},
+			map[string]interface{}{"json": "{}"}},
+		{"accounts_create",
+			map[string]interface{}{"keys": []interface{}{map[string]interface{}{"private": "K", "account": "A"}}},
+			map[string]interface{}{"keys": []interface{}{map[string]interface{}{"private": "[REDACTED]", "account": "A"}}}},
+		{"version", "1", "1"},
+	}
+
+	for _, tt := range tests {
+		if got := rpc.Redact(tt.action, tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Redact(%s, %v) = %v, want %v", tt.action, tt.in, got, tt.want)
+		}
+	}
+
+	// The original is left as is.
+	payload := map[string]interface{}{"seed": "S"}
+	rpc.Redact("wallet_change_seed", payload)
+	if payload["seed"] != "S" {
+		t.Errorf("Redact changed its argument to %v", payload)
+	}
+}
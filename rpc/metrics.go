@@ -0,0 +1,225 @@
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Upper bounds of the latency histogram buckets, in seconds.
+var latencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30}
+
+// Upper bounds of the response size histogram buckets, in bytes.
+var sizeBuckets = []float64{256, 1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20, 16 << 20}
+
+// Metrics collects per action call counts, errors by type, latency and
+// response sizes, and serves them in the Prometheus text exposition format.
+// Streamed responses are not read by interceptors, so their sizes are
+// left out.
+type Metrics struct {
+	mu      sync.Mutex
+	actions map[string]*actionMetrics
+}
+
+type actionMetrics struct {
+	calls   uint64
+	errors  map[string]uint64
+	latency histogram
+	size    histogram
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func (h *histogram) observe(bounds []float64, v float64) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(bounds))
+	}
+
+	for i, b := range bounds {
+		if v <= b {
+			h.counts[i]++
+		}
+	}
+	h.sum += v
+	h.count++
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{actions: make(map[string]*actionMetrics)}
+}
+
+// Collects metrics of every call into m.
+func WithMetrics(m *Metrics) Option {
+	return WithInterceptors(m.Interceptor())
+}
+
+// Returns an interceptor recording every attempt into m.
+func (m *Metrics) Interceptor() Interceptor {
+	return func(ctx context.Context, action string, payload map[string]interface{}, next Invoker) ([]byte, error) {
+		start := time.Now()
+		raw, err := next(ctx, action, payload)
+
+		// Streamed calls succeed without a response.
+		size := len(raw)
+		if raw == nil && err == nil {
+			size = -1
+		}
+		m.observe(action, time.Since(start), size, err)
+
+		return raw, err
+	}
+}
+
+// Records an attempt, and its response size unless size is negative.
+func (m *Metrics) observe(action string, latency time.Duration, size int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, ok := m.actions[action]
+	if !ok {
+		a = &actionMetrics{errors: make(map[string]uint64)}
+		m.actions[action] = a
+	}
+
+	a.calls++
+	a.latency.observe(latencyBuckets, latency.Seconds())
+	if size >= 0 {
+		a.size.observe(sizeBuckets, float64(size))
+	}
+
+	if err != nil {
+		a.errors[errorType(err)]++
+	}
+}
+
+// Classifies err for the type label of the error counter.
+func errorType(err error) string {
+	var nodeErr *NodeError
+	var statusErr *StatusError
+	var netErr net.Error
+
+	switch {
+	case errors.As(err, &nodeErr):
+		return "node"
+	case errors.As(err, &statusErr):
+		return "status"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return "timeout"
+		}
+		return "network"
+	default:
+		return "other"
+	}
+}
+
+// Serves the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// Writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	actions := make([]string, 0, len(m.actions))
+	for action := range m.actions {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+
+	fmt.Fprintln(cw, "# HELP nano_rpc_calls_total RPC calls sent to the node, by action.")
+	fmt.Fprintln(cw, "# TYPE nano_rpc_calls_total counter")
+	for _, action := range actions {
+		fmt.Fprintf(cw, "nano_rpc_calls_total{action=%q} %d\n", action, m.actions[action].calls)
+	}
+
+	fmt.Fprintln(cw, "# HELP nano_rpc_errors_total Failed RPC calls, by action and error type.")
+	fmt.Fprintln(cw, "# TYPE nano_rpc_errors_total counter")
+	for _, action := range actions {
+		errs := m.actions[action].errors
+		types := make([]string, 0, len(errs))
+		for typ := range errs {
+			types = append(types, typ)
+		}
+		sort.Strings(types)
+
+		for _, typ := range types {
+			fmt.Fprintf(cw, "nano_rpc_errors_total{action=%q,type=%q} %d\n", action, typ, errs[typ])
+		}
+	}
+
+	fmt.Fprintln(cw, "# HELP nano_rpc_latency_seconds Latency of RPC calls, by action.")
+	fmt.Fprintln(cw, "# TYPE nano_rpc_latency_seconds histogram")
+	for _, action := range actions {
+		writeHistogram(cw, "nano_rpc_latency_seconds", action, latencyBuckets, &m.actions[action].latency)
+	}
+
+	fmt.Fprintln(cw, "# HELP nano_rpc_response_bytes Size of RPC responses, by action.")
+	fmt.Fprintln(cw, "# TYPE nano_rpc_response_bytes histogram")
+	for _, action := range actions {
+		writeHistogram(cw, "nano_rpc_response_bytes", action, sizeBuckets, &m.actions[action].size)
+	}
+
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+
+	return cw.n, cw.err
+}
+
+func writeHistogram(w io.Writer, name, action string, bounds []float64, h *histogram) {
+	for i, b := range bounds {
+		var n uint64
+		if h.counts != nil {
+			n = h.counts[i]
+		}
+		fmt.Fprintf(w, "%s_bucket{action=%q,le=%q} %d\n", name, action, formatFloat(b), n)
+	}
+
+	fmt.Fprintf(w, "%s_bucket{action=%q,le=\"+Inf\"} %d\n", name, action, h.count)
+	fmt.Fprintf(w, "%s_sum{action=%q} %s\n", name, action, formatFloat(h.sum))
+	fmt.Fprintf(w, "%s_count{action=%q} %d\n", name, action, h.count)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+
+	return n, err
+}
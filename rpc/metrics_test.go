@@ -0,0 +1,98 @@
+package rpc_test
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+func TestMetrics(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	n.OnRequest(failFirst("block_count", 1))
+
+	m := rpc.NewMetrics()
+	c := n.Client(rpc.WithMetrics(m), testRetry)
+	ctx := context.Background()
+
+	// Two attempts, the first failing with a 503.
+	if _, err := c.BlockCount(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AccountKey(ctx, "nano_1111"); err == nil {
+		t.Fatal("account_key of a bad account succeeded")
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	c.Version(canceled)
+
+	// Streamed responses have no size.
+	for _, err := range c.StreamFrontiers(ctx, n.Genesis(), 10) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	written, err := m.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != int64(buf.Len()) {
+		t.Errorf("WriteTo returned %d, wrote %d bytes", written, buf.Len())
+	}
+
+	lines := make(map[string]bool)
+	for _, line := range strings.Split(buf.String(), "\n") {
+		lines[line] = true
+	}
+
+	for _, want := range []string{
+		"# TYPE nano_rpc_calls_total counter",
+		`nano_rpc_calls_total{action="account_key"} 1`,
+		`nano_rpc_calls_total{action="block_count"} 2`,
+		`nano_rpc_calls_total{action="version"} 1`,
+		"# TYPE nano_rpc_errors_total counter",
+		`nano_rpc_errors_total{action="account_key",type="node"} 1`,
+		`nano_rpc_errors_total{action="block_count",type="status"} 1`,
+		`nano_rpc_errors_total{action="version",type="canceled"} 1`,
+		"# TYPE nano_rpc_latency_seconds histogram",
+		`nano_rpc_latency_seconds_bucket{action="block_count",le="30"} 2`,
+		`nano_rpc_latency_seconds_bucket{action="block_count",le="+Inf"} 2`,
+		`nano_rpc_latency_seconds_count{action="block_count"} 2`,
+		"# TYPE nano_rpc_response_bytes histogram",
+		`nano_rpc_response_bytes_bucket{action="block_count",le="256"} 2`,
+		`nano_rpc_response_bytes_count{action="block_count"} 2`,
+		`nano_rpc_response_bytes_sum{action="version"} 0`,
+		`nano_rpc_calls_total{action="frontiers"} 1`,
+		`nano_rpc_response_bytes_count{action="frontiers"} 0`,
+	} {
+		if !lines[want] {
+			t.Errorf("missing line %s", want)
+		}
+	}
+
+	if strings.Contains(buf.String(), `action="block_count",type="node"`) {
+		t.Error("block_count has node errors")
+	}
+}
+
+func TestMetricsHandler(t *testing.T) {
+	m := rpc.NewMetrics()
+
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("content type %q", ct)
+	}
+	if !strings.Contains(w.Body.String(), "# TYPE nano_rpc_calls_total counter") {
+		t.Errorf("body %q has no calls counter", w.Body.String())
+	}
+}
@@ -0,0 +1,84 @@
+package rpc
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a Client created by NewClient.
+type Option func(*Client)
+
+// Uses hc to send requests instead of http.DefaultClient.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// Uses rt as the transport of the underlying http.Client,
+// e.g. to configure a proxy or mTLS.
+func WithRoundTripper(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.roundTripper = rt
+	}
+}
+
+// Bounds every request to the node by d.
+// The deadline of the caller's context still applies if it is earlier.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// Adds a static header to every request, e.g. an API key
+// or bearer token required by a hosted RPC proxy.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.header.Add(key, value)
+	}
+}
+
+// Authenticates every request with HTTP basic auth.
+func WithBasicAuth(username, password string) Option {
+	return func(c *Client) {
+		c.username = username
+		c.password = password
+		c.basicAuth = true
+	}
+}
+
+// Sets the User-Agent header of every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// Fails requests whose response is larger than n bytes with
+// ErrResponseTooLarge instead of reading it into memory.
+// Streamed responses are checked as they are read.
+func WithMaxResponseSize(n int64) Option {
+	return func(c *Client) {
+		c.maxResponse = n
+	}
+}
+
+// Sends requests through t instead of HTTP or IPC, e.g. to replay
+// recorded responses. The URL given to NewClient is ignored.
+// Pools can't use it, their requests fail with ErrPoolTransport.
+func WithTransport(t Transport) Option {
+	return func(c *Client) {
+		c.transport = t
+	}
+}
+
+// Wraps the transport of the client, or of every node of a pool, with
+// wrap, e.g. to record requests as they go out. Wrappers see requests
+// as sent, after interceptors changed them. The last wrapper given is
+// the outermost one.
+func WithTransportWrapper(wrap func(Transport) Transport) Option {
+	return func(c *Client) {
+		c.wrappers = append(c.wrappers, wrap)
+	}
+}
@@ -0,0 +1,160 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+// Returns a hook keeping the headers of every request, and
+// a func returning the headers of the last one.
+func headerHook() (rpctest.Hook, func() http.Header) {
+	var mu sync.Mutex
+	var last http.Header
+
+	hook := func(w http.ResponseWriter, r *http.Request, action string) bool {
+		mu.Lock()
+		last = r.Header.Clone()
+		mu.Unlock()
+
+		return false
+	}
+
+	return hook, func() http.Header {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return last
+	}
+}
+
+func TestRequestHeaders(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	hook, header := headerHook()
+	n.OnRequest(hook)
+
+	c := n.Client(
+		rpc.WithHeader("Authorization", "Bearer secret"),
+		rpc.WithHeader("X-Api-Key", "a"),
+		rpc.WithHeader("X-Api-Key", "b"),
+		rpc.WithUserAgent("wallet/1.0"),
+	)
+	if _, err := c.BlockCount(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	h := header()
+	if got := h.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization = %q", got)
+	}
+	if got := h.Values("X-Api-Key"); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("X-Api-Key = %q, want both values", got)
+	}
+	if got := h.Get("User-Agent"); got != "wallet/1.0" {
+		t.Errorf("User-Agent = %q", got)
+	}
+	if got := h.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q", got)
+	}
+}
+
+func TestBasicAuth(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	hook, header := headerHook()
+	n.OnRequest(hook)
+
+	if _, err := n.Client(rpc.WithBasicAuth("user", "pass")).BlockCount(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	r := http.Request{Header: header()}
+	if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+		t.Errorf("basic auth = %q, %q, %v", user, pass, ok)
+	}
+
+	// Without the option no credentials are sent.
+	if _, err := n.Client().BlockCount(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := header().Get("Authorization"); got != "" {
+		t.Errorf("Authorization = %q without credentials", got)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	hook, release, _ := gate(nil)
+	n.OnRequest(hook)
+	defer close(release)
+
+	c := n.Client(rpc.WithTimeout(20 * time.Millisecond))
+
+	start := time.Now()
+	if _, err := c.BlockCount(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("call to a stuck node returned %v, want DeadlineExceeded", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("call with a timeout of 20ms took %v", d)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestRoundTripper(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	var calls int
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return http.DefaultTransport.RoundTrip(r)
+	})
+
+	hc := &http.Client{Timeout: time.Minute}
+	c := n.Client(rpc.WithHTTPClient(hc), rpc.WithRoundTripper(rt))
+	if _, err := c.BlockCount(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 1 {
+		t.Errorf("round tripper saw %d requests, want 1", calls)
+	}
+	if hc.Transport != nil {
+		t.Error("WithRoundTripper changed the given http.Client")
+	}
+}
+
+type transportFunc func(ctx context.Context, action string, request []byte) ([]byte, error)
+
+func (f transportFunc) RoundTrip(ctx context.Context, action string, request []byte) ([]byte, error) {
+	return f(ctx, action, request)
+}
+
+func TestTransport(t *testing.T) {
+	var actions []string
+	c := rpc.NewClient("http://unreachable.invalid", rpc.WithTransport(transportFunc(func(ctx context.Context, action string, request []byte) ([]byte, error) {
+		actions = append(actions, action)
+		return []byte(`{"count": "7", "unchecked": "0", "cemented": "7"}`), nil
+	})))
+
+	counts, err := c.BlockCount(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts["count"] != "7" || len(actions) != 1 || actions[0] != "block_count" {
+		t.Errorf("got %v through a transport seeing %v", counts, actions)
+	}
+}
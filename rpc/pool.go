@@ -0,0 +1,450 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Balancing selects the node serving a read action in a pool.
+type Balancing int
+
+const (
+	// Spreads reads evenly across healthy nodes.
+	RoundRobin Balancing = iota
+	// Sends reads to the healthy node with the lowest observed latency.
+	// Nodes are probed once before the first read to seed their latency.
+	LeastLatency
+)
+
+// PoolConfig describes a set of nodes served by a single Client.
+type PoolConfig struct {
+	// URLs of the nodes in the pool.
+	Endpoints []string
+	// URL of the node holding the wallets. Write actions and anything
+	// addressed to a wallet are pinned to it. Defaults to Endpoints[0].
+	Primary string
+	// How reads are spread across healthy nodes.
+	Balancing Balancing
+	// Nodes whose block count lags the highest one by more than
+	// MaxBlockLag blocks are marked unhealthy. Block counts of live
+	// nodes differ all the time, so this allows for some lag.
+	// Defaults to 1000 blocks.
+	MaxBlockLag uint64
+	// If set, health is checked in the background at this interval
+	// until the client is closed.
+	HealthCheckInterval time.Duration
+	// Without background health checks, nodes that failed a request
+	// are tried again after Cooldown. Defaults to 30 seconds.
+	Cooldown time.Duration
+}
+
+// NodeStatus reports the last known state of a node in a pool.
+type NodeStatus struct {
+	URL        string
+	Primary    bool
+	Healthy    bool
+	Version    string
+	BlockCount uint64
+	Latency    time.Duration
+	Err        error
+}
+
+var ErrNoEndpoints = errors.New("rpc: pool has no endpoints")
+
+// Returned by pools given WithTransport, which would send the
+// requests of every node through the same transport.
+var ErrPoolTransport = errors.New("rpc: pool can't use WithTransport")
+
+type poolNode struct {
+	client *Client
+
+	mu         sync.Mutex
+	healthy    bool
+	version    string
+	blockCount uint64
+	latency    time.Duration
+	err        error
+	// When the node last failed a request, zero
+	// if a health check decided on it since.
+	failedAt time.Time
+}
+
+type pool struct {
+	nodes     []*poolNode
+	primary   *poolNode
+	balancing Balancing
+	maxLag    uint64
+	// Zero if health is checked in the background.
+	cooldown time.Duration
+	next     atomic.Uint64
+	// Set once a health check seeded the latencies of the nodes.
+	seedMu sync.Mutex
+	seeded bool
+	stop   context.CancelFunc
+	// Returned by every request, for pools that can't be used.
+	err error
+}
+
+// Creates a client spreading requests over several nodes.
+// Read actions are balanced across healthy nodes and fail over to the
+// next one on transient errors. Write actions and wallet actions always
+// go to the primary node. opts apply to the requests sent to every node,
+// except WithTransport: requests of pools given it fail with ErrPoolTransport.
+func NewPoolClient(cfg PoolConfig, opts ...Option) *Client {
+	primary := cfg.Primary
+	if primary == "" && len(cfg.Endpoints) > 0 {
+		primary = cfg.Endpoints[0]
+	}
+
+	c := NewClient(primary, opts...)
+	p := &pool{
+		balancing: cfg.Balancing,
+		maxLag:    cfg.MaxBlockLag,
+	}
+	if p.maxLag == 0 {
+		p.maxLag = 1000
+	}
+
+	for _, url := range cfg.Endpoints {
+		nc := *c
+		nc.url = url
+		nc.transport = c.newTransport(url)
+		n := &poolNode{client: &nc, healthy: true}
+		p.nodes = append(p.nodes, n)
+		if url == primary {
+			p.primary = n
+		}
+	}
+
+	if p.primary == nil && primary != "" {
+		nc := *c
+		p.primary = &poolNode{client: &nc, healthy: true}
+		p.nodes = append(p.nodes, p.primary)
+	}
+
+	c.pool = p
+
+	if c.customTransport {
+		p.err = ErrPoolTransport
+		return c
+	}
+
+	if cfg.HealthCheckInterval <= 0 {
+		p.cooldown = cfg.Cooldown
+		if p.cooldown <= 0 {
+			p.cooldown = 30 * time.Second
+		}
+	}
+
+	if cfg.HealthCheckInterval > 0 && len(p.nodes) > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		p.stop = cancel
+		go p.run(ctx, cfg.HealthCheckInterval)
+	}
+
+	return c
+}
+
+// Stops background health checks of a pool client.
+func (c *Client) Close() error {
+	if c.pool != nil && c.pool.stop != nil {
+		c.pool.stop()
+	}
+
+	return nil
+}
+
+// Checks every node in the pool using the version and block_count
+// actions, marking unreachable and lagging nodes as unhealthy. The
+// checks skip interceptors, so caches and mocks can't answer them.
+// Returns ErrNoEndpoints for a client that is not a pool.
+func (c *Client) CheckHealth(ctx context.Context) error {
+	if c.pool == nil || len(c.pool.nodes) == 0 {
+		return ErrNoEndpoints
+	}
+
+	if c.pool.err != nil {
+		return c.pool.err
+	}
+
+	c.pool.check(ctx)
+
+	return nil
+}
+
+// Returns the state of every node in the pool, or nil
+// for a client that is not a pool.
+func (c *Client) NodeStatus() []NodeStatus {
+	if c.pool == nil {
+		return nil
+	}
+
+	status := make([]NodeStatus, len(c.pool.nodes))
+	for i, n := range c.pool.nodes {
+		n.mu.Lock()
+		status[i] = NodeStatus{
+			URL:        n.client.url,
+			Primary:    n == c.pool.primary,
+			Healthy:    n.healthy,
+			Version:    n.version,
+			BlockCount: n.blockCount,
+			Latency:    n.latency,
+			Err:        n.err,
+		}
+		n.mu.Unlock()
+	}
+
+	return status
+}
+
+func (p *pool) run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	p.check(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			p.check(ctx)
+		}
+	}
+}
+
+func (p *pool) check(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, n := range p.nodes {
+		wg.Add(1)
+		go func(n *poolNode) {
+			defer wg.Done()
+			n.probe(ctx)
+		}(n)
+	}
+	wg.Wait()
+
+	var highest uint64
+	for _, n := range p.nodes {
+		n.mu.Lock()
+		if n.err == nil && n.blockCount > highest {
+			highest = n.blockCount
+		}
+		n.mu.Unlock()
+	}
+
+	for _, n := range p.nodes {
+		n.mu.Lock()
+		n.healthy = n.err == nil && highest-n.blockCount <= p.maxLag
+		n.failedAt = time.Time{}
+		n.mu.Unlock()
+	}
+}
+
+// Queries version and block count of the node.
+func (n *poolNode) probe(ctx context.Context) {
+	start := time.Now()
+
+	var version string
+	v, err := n.fetch(ctx, "version")
+	if err == nil {
+		version = v["node_vendor"]
+		if version == "" {
+			version = v["node_version"]
+		}
+	}
+
+	var count uint64
+	if err == nil {
+		var r map[string]string
+		r, err = n.fetch(ctx, "block_count")
+		if err == nil {
+			count, err = strconv.ParseUint(r["count"], 10, 64)
+		}
+	}
+
+	// A probe cut short by the caller says nothing about the node.
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.err = err
+	if err != nil {
+		n.healthy = false
+		return
+	}
+
+	n.version = version
+	n.blockCount = count
+	n.observe(time.Since(start) / 2)
+}
+
+// Sends action straight to the node, bypassing the interceptors of
+// the client: an answer from a cache says nothing about the node.
+func (n *poolNode) fetch(ctx context.Context, action string) (map[string]string, error) {
+	raw, err := n.client.post(ctx, action, []byte(`{"action":"`+action+`"}`))
+	if err != nil {
+		return nil, err
+	}
+
+	var r map[string]string
+	if err = json.Unmarshal(raw, &r); err != nil {
+		return nil, malformed(action, err)
+	}
+
+	return r, nil
+}
+
+// Folds a successful request latency into the moving average.
+// Must be called with n.mu held.
+func (n *poolNode) observe(d time.Duration) {
+	if n.latency == 0 {
+		n.latency = d
+		return
+	}
+
+	n.latency = (n.latency*4 + d) / 5
+}
+
+// Reports whether action must be served by the primary node.
+func pinned(action string, payload map[string]interface{}) bool {
+	if ActionSafety(action) != Safe {
+		return true
+	}
+
+	_, wallet := payload["wallet"]
+
+	return wallet
+}
+
+// Reports whether the latency of the node was measured.
+func (n *poolNode) measured() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.latency > 0
+}
+
+// Returns the nodes to try for a read, in order of preference.
+func (p *pool) candidates() []*poolNode {
+	var healthy []*poolNode
+	for _, n := range p.nodes {
+		n.mu.Lock()
+		if !n.healthy && p.cooldown > 0 && !n.failedAt.IsZero() && time.Since(n.failedAt) >= p.cooldown {
+			n.healthy = true
+			n.failedAt = time.Time{}
+		}
+		if n.healthy {
+			healthy = append(healthy, n)
+		}
+		n.mu.Unlock()
+	}
+
+	if len(healthy) == 0 {
+		healthy = append(healthy, p.nodes...)
+	}
+
+	var first int
+	switch p.balancing {
+	case LeastLatency:
+		// Nodes not measured yet, e.g. because their
+		// probe failed, come after measured ones.
+		var best time.Duration
+		for i, n := range healthy {
+			n.mu.Lock()
+			l := n.latency
+			n.mu.Unlock()
+			if l == 0 {
+				l = time.Duration(math.MaxInt64)
+			}
+			if i == 0 || l < best {
+				first, best = i, l
+			}
+		}
+	default:
+		first = int(p.next.Add(1)-1) % len(healthy)
+	}
+
+	ordered := make([]*poolNode, 0, len(healthy))
+	ordered = append(ordered, healthy[first:]...)
+
+	return append(ordered, healthy[:first]...)
+}
+
+// Sends the request to the node chosen for action, failing
+// over to the next candidate on transient errors for reads.
+func (p *pool) post(ctx context.Context, action string, payload map[string]interface{}, body []byte) ([]byte, error) {
+	if len(p.nodes) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	if pinned(action, payload) {
+		return p.primary.post(ctx, action, body)
+	}
+
+	if p.balancing == LeastLatency {
+		p.seedLatencies(ctx)
+	}
+
+	var err error
+	for _, n := range p.candidates() {
+		var raw []byte
+		raw, err = n.post(ctx, action, body)
+		if err == nil || !IsRetryable(err) || ctx.Err() != nil || streamed(ctx) {
+			return raw, err
+		}
+
+		n.mu.Lock()
+		n.healthy = false
+		n.err = err
+		n.failedAt = time.Now()
+		n.mu.Unlock()
+	}
+
+	return nil, err
+}
+
+// Probes the nodes before the first read, unless all of them were
+// measured already. A check cut short by ctx is done again by the
+// next read.
+func (p *pool) seedLatencies(ctx context.Context) {
+	p.seedMu.Lock()
+	defer p.seedMu.Unlock()
+
+	if p.seeded {
+		return
+	}
+
+	for _, n := range p.nodes {
+		if !n.measured() {
+			p.check(ctx)
+			break
+		}
+	}
+
+	p.seeded = ctx.Err() == nil
+}
+
+func (n *poolNode) post(ctx context.Context, action string, body []byte) ([]byte, error) {
+	start := time.Now()
+	raw, err := n.client.post(ctx, action, body)
+	if err == nil {
+		n.mu.Lock()
+		n.observe(time.Since(start))
+		n.mu.Unlock()
+	}
+
+	return raw, err
+}
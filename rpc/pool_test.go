@@ -0,0 +1,278 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+func TestPoolLeastLatency(t *testing.T) {
+	slow := rpctest.NewNode()
+	defer slow.Close()
+	slow.OnRequest(func(w http.ResponseWriter, r *http.Request, action string) bool {
+		time.Sleep(30 * time.Millisecond)
+		return false
+	})
+	fast := rpctest.NewNode()
+	defer fast.Close()
+
+	c := rpc.NewPoolClient(rpc.PoolConfig{
+		Endpoints: []string{slow.URL, fast.URL},
+		Balancing: rpc.LeastLatency,
+	})
+	defer c.Close()
+
+	// Neither node was probed before the first read.
+	for i := 0; i < 5; i++ {
+		if _, _, err := c.AccountBalance(context.Background(), fast.Genesis()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := fast.Requests("account_balance"); got != 5 {
+		t.Errorf("fast node served %d reads, want 5", got)
+	}
+	if got := slow.Requests("account_balance"); got != 0 {
+		t.Errorf("slow node served %d reads, want 0", got)
+	}
+
+	for _, s := range c.NodeStatus() {
+		if s.Latency == 0 {
+			t.Errorf("latency of %s was not seeded", s.URL)
+		}
+	}
+}
+
+func TestPoolFailoverAndCooldown(t *testing.T) {
+	bad := rpctest.NewNode()
+	defer bad.Close()
+	bad.OnRequest(func(w http.ResponseWriter, r *http.Request, action string) bool {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return true
+	})
+	good := rpctest.NewNode()
+	defer good.Close()
+
+	c := rpc.NewPoolClient(rpc.PoolConfig{
+		Endpoints: []string{bad.URL, good.URL},
+		Primary:   good.URL,
+		Cooldown:  50 * time.Millisecond,
+	})
+	defer c.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		if _, _, err := c.AccountBalance(ctx, good.Genesis()); err != nil {
+			t.Fatalf("read %d: %v", i, err)
+		}
+	}
+
+	// The first read failed over, the others skipped the failed node.
+	if got := bad.Requests("account_balance"); got != 1 {
+		t.Errorf("failed node got %d reads, want 1", got)
+	}
+
+	for _, s := range c.NodeStatus() {
+		if s.URL == bad.URL && s.Healthy {
+			t.Errorf("failed node is still healthy")
+		}
+	}
+
+	// Once recovered, the node is back in rotation after the cooldown.
+	bad.OnRequest(nil)
+	time.Sleep(60 * time.Millisecond)
+
+	for i := 0; i < 4; i++ {
+		if _, _, err := c.AccountBalance(ctx, good.Genesis()); err != nil {
+			t.Fatalf("read %d: %v", i, err)
+		}
+	}
+
+	if got := bad.Requests("account_balance"); got < 2 {
+		t.Errorf("recovered node got %d reads in total, want it back in rotation", got)
+	}
+}
+
+func TestPoolPinned(t *testing.T) {
+	primary := rpctest.NewNode()
+	defer primary.Close()
+	other := rpctest.NewNode()
+	defer other.Close()
+
+	c := rpc.NewPoolClient(rpc.PoolConfig{
+		Endpoints: []string{other.URL, primary.URL},
+		Primary:   primary.URL,
+	})
+	defer c.Close()
+
+	ctx := context.Background()
+	wallet, err := c.CreateWallet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err = c.AccountList(ctx, wallet); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		action string
+		want   int
+	}{
+		{"wallet_create", 1},
+		{"account_list", 3},
+	}
+
+	for _, tt := range tests {
+		if got := primary.Requests(tt.action); got != tt.want {
+			t.Errorf("primary got %d %s requests, want %d", got, tt.action, tt.want)
+		}
+		if got := other.Requests(tt.action); got != 0 {
+			t.Errorf("other node got %d %s requests, want 0", got, tt.action)
+		}
+	}
+}
+
+// Nodes lagging a few blocks behind stay healthy, ones lagging
+// more than MaxBlockLag are marked unhealthy.
+func TestPoolBlockLag(t *testing.T) {
+	ahead := rpctest.NewNode()
+	defer ahead.Close()
+	behind := rpctest.NewNode()
+	defer behind.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := ahead.Fund(ahead.Genesis(), "1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		maxLag      uint64
+		wantHealthy bool
+	}{
+		{0, true},
+		{3, true},
+		{2, false},
+	}
+
+	for _, tt := range tests {
+		c := rpc.NewPoolClient(rpc.PoolConfig{
+			Endpoints:           []string{ahead.URL, behind.URL},
+			MaxBlockLag:         tt.maxLag,
+			HealthCheckInterval: 10 * time.Millisecond,
+		})
+		time.Sleep(50 * time.Millisecond)
+
+		for _, s := range c.NodeStatus() {
+			want := s.URL == ahead.URL || tt.wantHealthy
+			if s.Healthy != want || s.Err != nil {
+				t.Errorf("max lag %d: node at block %d healthy = %v (%v), want %v", tt.maxLag, s.BlockCount, s.Healthy, s.Err, want)
+			}
+		}
+		c.Close()
+	}
+}
+
+// Counts cached for one node must not hide the lag of another.
+func TestPoolBlockLagCached(t *testing.T) {
+	ahead := rpctest.NewNode()
+	defer ahead.Close()
+	behind := rpctest.NewNode()
+	defer behind.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := ahead.Fund(ahead.Genesis(), "1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := rpc.NewPoolClient(rpc.PoolConfig{
+		Endpoints:   []string{ahead.URL, behind.URL},
+		MaxBlockLag: 1,
+	}, rpc.WithCache(rpc.NewCache(rpc.NewLRUCache(16), time.Minute)))
+	defer c.Close()
+
+	// The second check would find both counts in the cache.
+	for i := 0; i < 2; i++ {
+		if err := c.CheckHealth(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, s := range c.NodeStatus() {
+		want := s.URL == ahead.URL
+		if s.Healthy != want || s.Err != nil {
+			t.Errorf("node at block %d healthy = %v (%v), want %v", s.BlockCount, s.Healthy, s.Err, want)
+		}
+	}
+
+	if n := ahead.Requests("block_count") + behind.Requests("block_count"); n != 4 {
+		t.Errorf("nodes got %d block_count requests, want 4", n)
+	}
+}
+
+// Seeding cut short by the context of the first read
+// is done again by the next one.
+func TestPoolSeedRetry(t *testing.T) {
+	var delayed atomic.Bool
+	slow := rpctest.NewNode()
+	defer slow.Close()
+	slow.OnRequest(func(w http.ResponseWriter, r *http.Request, action string) bool {
+		time.Sleep(30 * time.Millisecond)
+		return false
+	})
+	fast := rpctest.NewNode()
+	defer fast.Close()
+	// Only the first probe of the fast node is slow.
+	fast.OnRequest(func(w http.ResponseWriter, r *http.Request, action string) bool {
+		if delayed.CompareAndSwap(false, true) {
+			time.Sleep(30 * time.Millisecond)
+		}
+		return false
+	})
+
+	c := rpc.NewPoolClient(rpc.PoolConfig{
+		Endpoints: []string{slow.URL, fast.URL},
+		Balancing: rpc.LeastLatency,
+	})
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	c.AccountBalance(ctx, fast.Genesis())
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := c.AccountBalance(context.Background(), fast.Genesis()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := slow.Requests("account_balance"); got != 0 {
+		t.Errorf("slow node served %d reads, want 0", got)
+	}
+}
+
+func TestPoolTransport(t *testing.T) {
+	c := rpc.NewPoolClient(rpc.PoolConfig{
+		Endpoints: []string{"http://a.invalid", "http://b.invalid"},
+	}, rpc.WithTransport(transportFunc(func(ctx context.Context, action string, request []byte) ([]byte, error) {
+		return []byte(`{"count": "1"}`), nil
+	})))
+	defer c.Close()
+
+	if _, err := c.BlockCount(context.Background()); !errors.Is(err, rpc.ErrPoolTransport) {
+		t.Errorf("read returned %v, want ErrPoolTransport", err)
+	}
+	if err := c.CheckHealth(context.Background()); !errors.Is(err, rpc.ErrPoolTransport) {
+		t.Errorf("health check returned %v, want ErrPoolTransport", err)
+	}
+}
@@ -0,0 +1,212 @@
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	mrand "math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Safety classifies an RPC action by whether it can be retried
+// without side effects.
+type Safety int
+
+const (
+	// Actions that change state and must never be repeated blindly,
+	// e.g. process, receive or account_create. Unknown actions are unsafe.
+	Unsafe Safety = iota
+	// Read-only actions like account_info or block_count.
+	Safe
+	// Actions made idempotent by a key in their payload, i.e. send with
+	// its id. Client.Send always sends one.
+	Keyed
+)
+
+// Idempotency class of every action wrapped by this package.
+// Anything not listed here is treated as Unsafe.
+var actionSafety = map[string]Safety{
+	"account_balance":         Safe,
+	"account_block_count":     Safe,
+	"account_get":             Safe,
+	"account_history":         Safe,
+	"account_info":            Safe,
+	"account_key":             Safe,
+	"account_list":            Safe,
+	"account_representative":  Safe,
+	"account_weight":          Safe,
+	"accounts_balances":       Safe,
+	"accounts_frontiers":      Safe,
+	"accounts_pending":        Safe,
+	"accounts_receivable":     Safe,
+	"available_supply":        Safe,
+	"block":                   Safe,
+	"block_account":           Safe,
+	"block_count":             Safe,
+	"block_count_type":        Safe,
+	"blocks":                  Safe,
+	"blocks_info":             Safe,
+	"chain":                   Safe,
+	"delegators":              Safe,
+	"delegators_count":        Safe,
+	"deterministic_key":       Safe,
+	"frontier_count":          Safe,
+	"frontiers":               Safe,
+	"history":                 Safe,
+	"key_expand":              Safe,
+	"ledger":                  Safe,
+	"password_locked":         Safe,
+	"password_valid":          Safe,
+	"peers":                   Safe,
+	"pending":                 Safe,
+	"pending_exists":          Safe,
+	"receivable":              Safe,
+	"receivable_exists":       Safe,
+	"receive_minimum":         Safe,
+	"representatives":         Safe,
+	"successors":              Safe,
+	"unchecked":               Safe,
+	"unchecked_get":           Safe,
+	"unchecked_keys":          Safe,
+	"validate_account_number": Safe,
+	"version":                 Safe,
+	"wallet_balance_total":    Safe,
+	"wallet_balances":         Safe,
+	"wallet_contains":         Safe,
+	"wallet_frontiers":        Safe,
+	"wallet_pending":          Safe,
+	"wallet_receivable":       Safe,
+	"wallet_representative":   Safe,
+	"wallet_work_get":         Safe,
+	"work_get":                Safe,
+	"work_peers":              Safe,
+	"work_validate":           Safe,
+
+	"send": Keyed,
+}
+
+// Returns the idempotency class of action.
+func ActionSafety(action string) Safety {
+	return actionSafety[action]
+}
+
+// RetryPolicy controls how failed requests are retried.
+// Only Safe actions and Keyed actions carrying a key are retried.
+type RetryPolicy struct {
+	// Total number of attempts, including the first one.
+	MaxAttempts int
+	// Delay before the first retry, doubled on every further attempt.
+	BaseDelay time.Duration
+	// Upper bound of the delay between attempts.
+	MaxDelay time.Duration
+	// Reports whether err is worth retrying. Defaults to IsRetryable.
+	Retryable func(err error) bool
+}
+
+// Retries transient failures up to three times.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+	Retryable:   IsRetryable,
+}
+
+// Retries failed requests according to p.
+func WithRetry(p RetryPolicy) Option {
+	return func(c *Client) {
+		if p.Retryable == nil {
+			p.Retryable = IsRetryable
+		}
+
+		c.retry = &p
+	}
+}
+
+// Reports whether err is a transient failure: a network error,
+// a per-request timeout, a truncated response or an overloaded
+// node or proxy. Errors reported by the node itself are not retryable.
+func IsRetryable(err error) bool {
+	var nodeErr *NodeError
+	if errors.As(err, &nodeErr) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// Reports whether action with payload may be sent again after a failure.
+func canRetry(action string, payload map[string]interface{}) bool {
+	switch ActionSafety(action) {
+	case Safe:
+		return true
+	case Keyed:
+		id, _ := payload["id"].(string)
+		return id != ""
+	default:
+		return false
+	}
+}
+
+// Returns the delay before retry number attempt (starting at 1),
+// with equal jitter to spread out clients retrying together.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay << (attempt - 1)
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+
+	if d <= 0 {
+		return 0
+	}
+
+	half := d / 2
+
+	return half + mrand.N(d-half+1)
+}
+
+// Waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// Returns a random id for Send. Passing the same id to every
+// attempt of a send, including ones made after a restart,
+// makes sure it spends only once.
+func NewSendID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
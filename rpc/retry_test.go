@@ -0,0 +1,215 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+// Returns a hook answering the first failures requests
+// of action with 503 before the node sees them.
+func failFirst(action string, failures int) rpctest.Hook {
+	var mu sync.Mutex
+	var seen int
+
+	return func(w http.ResponseWriter, r *http.Request, a string) bool {
+		if a != action {
+			return false
+		}
+
+		mu.Lock()
+		seen++
+		fail := seen <= failures
+		mu.Unlock()
+
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		return fail
+	}
+}
+
+var testRetry = rpc.WithRetry(rpc.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+
+// Creates a wallet holding an account funded with amount raw,
+// already received. Returns the wallet and the account.
+func fundedWallet(t *testing.T, n *rpctest.Node, c *rpc.Client, amount string) (string, string) {
+	t.Helper()
+	ctx := context.Background()
+
+	wallet, err := c.CreateWallet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := c.CreateAccount(ctx, wallet, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := n.Fund(account, amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = c.ReceiveBlock(ctx, wallet, account, hash, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	return wallet, account
+}
+
+// Sends are retried with the id given or, without one, an id of their
+// own, so the retry spends once.
+func TestRetrySend(t *testing.T) {
+	for _, id := range []string{rpc.NewSendID(), ""} {
+		n := rpctest.NewNode()
+		defer n.Close()
+		c := n.Client(testRetry)
+		wallet, account := fundedWallet(t, n, c, "1000")
+
+		var ids []string
+		fail := failFirst("send", 1)
+		n.OnRequest(func(w http.ResponseWriter, r *http.Request, action string) bool {
+			if action == "send" {
+				var req struct {
+					ID string `json:"id"`
+				}
+				json.NewDecoder(r.Body).Decode(&req)
+				ids = append(ids, req.ID)
+			}
+
+			return fail(w, r, action)
+		})
+
+		if _, err := c.Send(context.Background(), wallet, account, n.Genesis(), id, 10, ""); err != nil {
+			t.Errorf("id %q: send returned %v", id, err)
+		}
+
+		if len(ids) != 2 || ids[0] == "" || ids[0] != ids[1] || (id != "" && ids[0] != id) {
+			t.Errorf("id %q: attempts sent ids %q, want the same one twice", id, ids)
+		}
+
+		if balance, _, err := c.AccountBalance(context.Background(), account); err != nil || balance != "990" {
+			t.Errorf("id %q: balance = %s, %v, want 990", id, balance, err)
+		}
+	}
+}
+
+func TestRetrySendOnce(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+	wallet, account := fundedWallet(t, n, c, "1000")
+
+	// A send repeated with its id returns the first block
+	// instead of spending again.
+	id := rpc.NewSendID()
+	first, err := c.Send(ctx, wallet, account, n.Genesis(), id, 10, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := c.Send(ctx, wallet, account, n.Genesis(), id, 10, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first != second {
+		t.Errorf("repeated send returned block %s, want %s", second, first)
+	}
+
+	balance, _, err := c.AccountBalance(ctx, account)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if balance != "990" {
+		t.Errorf("balance = %s, want 990", balance)
+	}
+}
+
+func TestRetryBySafety(t *testing.T) {
+	tests := []struct {
+		action    string
+		wantCalls int
+	}{
+		{"block_count", 3},
+		{"account_balance", 3},
+		{"process", 1},
+		{"receive", 1},
+		{"account_create", 1},
+		{"work_generate", 1},
+		{"unknown_action", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			n := rpctest.NewNode()
+			defer n.Close()
+			n.OnRequest(failFirst(tt.action, 5))
+			c := n.Client(testRetry)
+
+			err := c.Call(context.Background(), tt.action, nil, nil)
+
+			var statusErr *rpc.StatusError
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+				t.Errorf("err = %v, want a 503 *rpc.StatusError", err)
+			}
+
+			if got := n.Requests(tt.action); got != tt.wantCalls {
+				t.Errorf("%s was attempted %d times, want %d", tt.action, got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestActionSafety(t *testing.T) {
+	tests := []struct {
+		action string
+		want   rpc.Safety
+	}{
+		{"account_info", rpc.Safe},
+		{"send", rpc.Keyed},
+		{"process", rpc.Unsafe},
+		{"not_an_action", rpc.Unsafe},
+	}
+
+	for _, tt := range tests {
+		if got := rpc.ActionSafety(tt.action); got != tt.want {
+			t.Errorf("ActionSafety(%q) = %v, want %v", tt.action, got, tt.want)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"node error", &rpc.NodeError{Action: "send", Message: "Wallet is locked"}, false},
+		{"503", &rpc.StatusError{Action: "send", StatusCode: http.StatusServiceUnavailable}, true},
+		{"429", &rpc.StatusError{Action: "send", StatusCode: http.StatusTooManyRequests}, true},
+		{"400", &rpc.StatusError{Action: "send", StatusCode: http.StatusBadRequest}, false},
+		{"deadline", context.DeadlineExceeded, true},
+		{"canceled", context.Canceled, false},
+		{"truncated", io.ErrUnexpectedEOF, true},
+	}
+
+	for _, tt := range tests {
+		if got := rpc.IsRetryable(tt.err); got != tt.want {
+			t.Errorf("%s: IsRetryable = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,970 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package rpcmock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/s1na/nano-go/rpc"
+)
+
+// API is a mock of rpc.API.
+type API struct {
+	GetAccountFunc               func(context.Context, string) (string, error)
+	AccountInfoFunc              func(context.Context, string, bool, bool, bool, bool) (*rpc.Account, error)
+	AccountsInfoFunc             func(context.Context, []string, bool, bool, bool, bool) (map[string]*rpc.Account, error)
+	AccountBalanceFunc           func(context.Context, string) (string, string, error)
+	AccountBlockCountFunc        func(context.Context, string) (int, error)
+	AccountHistoryFunc           func(context.Context, string, int) ([]map[string]string, error)
+	AccountKeyFunc               func(context.Context, string) (string, error)
+	AccountRepresentativeFunc    func(context.Context, string) (string, error)
+	AccountWeightFunc            func(context.Context, string) (string, error)
+	AccountsBalancesFunc         func(context.Context, []string) (map[string]map[string]string, error)
+	AccountsFrontiersFunc        func(context.Context, []string) (map[string]string, error)
+	AccountsPendingFunc          func(context.Context, []string, int, string, string) (map[string]interface{}, error)
+	DelegatorsFunc               func(context.Context, string) (map[string]string, error)
+	DelegatorsCountFunc          func(context.Context, string) (int, error)
+	FrontiersFunc                func(context.Context, string, int) (map[string]string, error)
+	ValidateAccountNumberFunc    func(context.Context, string) (bool, error)
+	PendingFunc                  func(context.Context, string, int, int, bool) (interface{}, error)
+	PendingExistsFunc            func(context.Context, string) (bool, error)
+	GetBlockFunc                 func(context.Context, string) (rpc.Block, error)
+	BlocksFunc                   func(context.Context, []string) (map[string]rpc.Block, error)
+	BlocksInfoFunc               func(context.Context, []string, bool, bool) (map[string]*rpc.BlockInfo, error)
+	BlockAccountFunc             func(context.Context, string) (string, error)
+	BlockCountFunc               func(context.Context) (map[string]string, error)
+	BlockCountTypeFunc           func(context.Context) (map[string]string, error)
+	SuccessorsFunc               func(context.Context, string, int) ([]string, error)
+	ChainFunc                    func(context.Context, string, int) ([]string, error)
+	HistoryFunc                  func(context.Context, string, int) ([]map[string]string, error)
+	AvailableSupplyFunc          func(context.Context) (string, error)
+	FrontierCountFunc            func(context.Context) (int, error)
+	RepresentativesFunc          func(context.Context, int, bool) (map[string]string, error)
+	LedgerFunc                   func(context.Context, string, int, bool, bool, bool, bool) (map[string]*rpc.Account, error)
+	UncheckedBlocksFunc          func(context.Context, int) (map[string]rpc.Block, error)
+	GetUncheckedBlockFunc        func(context.Context, string) (rpc.Block, error)
+	UncheckedKeysFunc            func(context.Context, string, int) ([]rpc.UncheckedEntry, error)
+	CreateWalletFunc             func(context.Context) (string, error)
+	DestroyWalletFunc            func(context.Context, string) error
+	ExportWalletFunc             func(context.Context, string) (string, error)
+	ChangeWalletSeedFunc         func(context.Context, string, string) (bool, error)
+	WalletAddFunc                func(context.Context, string, string, bool) (string, error)
+	WalletContainsFunc           func(context.Context, string, string) (bool, error)
+	CreateAccountFunc            func(context.Context, string, bool) (string, error)
+	CreateAccountsFunc           func(context.Context, string, int, bool) ([]string, error)
+	AccountListFunc              func(context.Context, string) ([]string, error)
+	MoveAccountsFunc             func(context.Context, string, string, []string) (bool, error)
+	RemoveAccountFunc            func(context.Context, string, string) (bool, error)
+	SetAccountRepresentativeFunc func(context.Context, string, string, string, string) (string, error)
+	WalletRepresentativeFunc     func(context.Context, string) (string, error)
+	SetWalletRepresentativeFunc  func(context.Context, string, string) (bool, error)
+	WalletTotalBalanceFunc       func(context.Context, string) (map[string]string, error)
+	WalletBalancesFunc           func(context.Context, string, int) (map[string]map[string]string, error)
+	WalletFrontiersFunc          func(context.Context, string) (map[string]string, error)
+	WalletPendingFunc            func(context.Context, string, int, int, bool) (map[string]interface{}, error)
+	WalletRepublishFunc          func(context.Context, string, int) ([]string, error)
+	WalletWorkGetFunc            func(context.Context, string) (map[string]string, error)
+	GetWorkFunc                  func(context.Context, string, string) (string, error)
+	SetWorkFunc                  func(context.Context, string, string, string) (bool, error)
+	ChangeWalletPasswordFunc     func(context.Context, string, string) (bool, error)
+	EnterWalletPasswordFunc      func(context.Context, string, string) (bool, error)
+	WalletPasswordValidFunc      func(context.Context, string, string) (bool, error)
+	IsWalletLockedFunc           func(context.Context, string) (bool, error)
+	SendFunc                     func(context.Context, string, string, string, string, int, string) (string, error)
+	ReceiveBlockFunc             func(context.Context, string, string, string, string) (string, error)
+	SearchPendingFunc            func(context.Context, string) (bool, error)
+	SearchAllPendingFunc         func(context.Context) (bool, error)
+	GetReceiveMinimumFunc        func(context.Context) (string, error)
+	SetReceiveMinimumFunc        func(context.Context, string) (bool, error)
+	BeginPaymentFunc             func(context.Context, string) (string, error)
+	InitPaymentFunc              func(context.Context, string) (string, error)
+	EndPaymentFunc               func(context.Context, string, string) error
+	WaitPaymentFunc              func(context.Context, string, string, int) (string, error)
+	CreateOpenBlockFunc          func(context.Context, string, string, string, string, string) (string, *rpc.LegacyOpenBlock, error)
+	CreateReceiveBlockFunc       func(context.Context, string, string, string, string, string) (string, *rpc.LegacyReceiveBlock, error)
+	CreateSendBlockFunc          func(context.Context, string, string, string, string, string, string, string) (string, *rpc.LegacySendBlock, error)
+	CreateChangeBlockFunc        func(context.Context, string, string, string, string, string) (string, *rpc.LegacyChangeBlock, error)
+	CreateStateBlockFunc         func(context.Context, string, rpc.CreateStateBlockRequest) (string, *rpc.StateBlock, error)
+	ProcessBlockFunc             func(context.Context, rpc.Block, string, bool, bool) (string, error)
+	RepublishFunc                func(context.Context, string, int, int, int) ([]string, error)
+	GenerateWorkFunc             func(context.Context, string) (string, error)
+	CancelWorkFunc               func(context.Context, string) error
+	ValidateWorkFunc             func(context.Context, string, string) (bool, error)
+	AddWorkPeerFunc              func(context.Context, string, string) (bool, error)
+	GetWorkPeersFunc             func(context.Context) ([]string, error)
+	ClearWorkPeersFunc           func(context.Context) (bool, error)
+	VersionFunc                  func(context.Context) (map[string]string, error)
+	CapabilitiesFunc             func(context.Context) (rpc.Capabilities, error)
+	PeersFunc                    func(context.Context) (map[string]string, error)
+	SendKeepaliveFunc            func(context.Context, string, int) error
+	BootstrapFunc                func(context.Context, string, int) (bool, error)
+	BootstrapAnyFunc             func(context.Context) (bool, error)
+	ClearUncheckedBlocksFunc     func(context.Context) (bool, error)
+	DeterministicKeyFunc         func(context.Context, string, int) (map[string]string, error)
+	KeyCreateFunc                func(context.Context) (map[string]string, error)
+	KeyExpandFunc                func(context.Context, string) (map[string]string, error)
+	StopFunc                     func(context.Context) (bool, error)
+	CallFunc                     func(context.Context, string, interface{}, interface{}) error
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ rpc.API = (*API)(nil)
+
+func (m *API) GetAccount(ctx context.Context, key string) (string, error) {
+	m.record("GetAccount", []interface{}{key})
+	if m.GetAccountFunc == nil {
+		var r0 string
+		return r0, notMocked("GetAccount")
+	}
+	return m.GetAccountFunc(ctx, key)
+}
+
+func (m *API) AccountInfo(ctx context.Context, account string, representative bool, weight bool, pending bool, includeConfirmed bool) (*rpc.Account, error) {
+	m.record("AccountInfo", []interface{}{account, representative, weight, pending, includeConfirmed})
+	if m.AccountInfoFunc == nil {
+		var r0 *rpc.Account
+		return r0, notMocked("AccountInfo")
+	}
+	return m.AccountInfoFunc(ctx, account, representative, weight, pending, includeConfirmed)
+}
+
+func (m *API) AccountsInfo(ctx context.Context, accounts []string, representative bool, weight bool, pending bool, includeConfirmed bool) (map[string]*rpc.Account, error) {
+	m.record("AccountsInfo", []interface{}{accounts, representative, weight, pending, includeConfirmed})
+	if m.AccountsInfoFunc == nil {
+		var r0 map[string]*rpc.Account
+		return r0, notMocked("AccountsInfo")
+	}
+	return m.AccountsInfoFunc(ctx, accounts, representative, weight, pending, includeConfirmed)
+}
+
+func (m *API) AccountBalance(ctx context.Context, account string) (string, string, error) {
+	m.record("AccountBalance", []interface{}{account})
+	if m.AccountBalanceFunc == nil {
+		var r0 string
+		var r1 string
+		return r0, r1, notMocked("AccountBalance")
+	}
+	return m.AccountBalanceFunc(ctx, account)
+}
+
+func (m *API) AccountBlockCount(ctx context.Context, account string) (int, error) {
+	m.record("AccountBlockCount", []interface{}{account})
+	if m.AccountBlockCountFunc == nil {
+		var r0 int
+		return r0, notMocked("AccountBlockCount")
+	}
+	return m.AccountBlockCountFunc(ctx, account)
+}
+
+func (m *API) AccountHistory(ctx context.Context, account string, count int) ([]map[string]string, error) {
+	m.record("AccountHistory", []interface{}{account, count})
+	if m.AccountHistoryFunc == nil {
+		var r0 []map[string]string
+		return r0, notMocked("AccountHistory")
+	}
+	return m.AccountHistoryFunc(ctx, account, count)
+}
+
+func (m *API) AccountKey(ctx context.Context, account string) (string, error) {
+	m.record("AccountKey", []interface{}{account})
+	if m.AccountKeyFunc == nil {
+		var r0 string
+		return r0, notMocked("AccountKey")
+	}
+	return m.AccountKeyFunc(ctx, account)
+}
+
+func (m *API) AccountRepresentative(ctx context.Context, account string) (string, error) {
+	m.record("AccountRepresentative", []interface{}{account})
+	if m.AccountRepresentativeFunc == nil {
+		var r0 string
+		return r0, notMocked("AccountRepresentative")
+	}
+	return m.AccountRepresentativeFunc(ctx, account)
+}
+
+func (m *API) AccountWeight(ctx context.Context, account string) (string, error) {
+	m.record("AccountWeight", []interface{}{account})
+	if m.AccountWeightFunc == nil {
+		var r0 string
+		return r0, notMocked("AccountWeight")
+	}
+	return m.AccountWeightFunc(ctx, account)
+}
+
+func (m *API) AccountsBalances(ctx context.Context, accounts []string) (map[string]map[string]string, error) {
+	m.record("AccountsBalances", []interface{}{accounts})
+	if m.AccountsBalancesFunc == nil {
+		var r0 map[string]map[string]string
+		return r0, notMocked("AccountsBalances")
+	}
+	return m.AccountsBalancesFunc(ctx, accounts)
+}
+
+func (m *API) AccountsFrontiers(ctx context.Context, accounts []string) (map[string]string, error) {
+	m.record("AccountsFrontiers", []interface{}{accounts})
+	if m.AccountsFrontiersFunc == nil {
+		var r0 map[string]string
+		return r0, notMocked("AccountsFrontiers")
+	}
+	return m.AccountsFrontiersFunc(ctx, accounts)
+}
+
+func (m *API) AccountsPending(ctx context.Context, accounts []string, count int, threshold string, source string) (map[string]interface{}, error) {
+	m.record("AccountsPending", []interface{}{accounts, count, threshold, source})
+	if m.AccountsPendingFunc == nil {
+		var r0 map[string]interface{}
+		return r0, notMocked("AccountsPending")
+	}
+	return m.AccountsPendingFunc(ctx, accounts, count, threshold, source)
+}
+
+func (m *API) Delegators(ctx context.Context, account string) (map[string]string, error) {
+	m.record("Delegators", []interface{}{account})
+	if m.DelegatorsFunc == nil {
+		var r0 map[string]string
+		return r0, notMocked("Delegators")
+	}
+	return m.DelegatorsFunc(ctx, account)
+}
+
+func (m *API) DelegatorsCount(ctx context.Context, account string) (int, error) {
+	m.record("DelegatorsCount", []interface{}{account})
+	if m.DelegatorsCountFunc == nil {
+		var r0 int
+		return r0, notMocked("DelegatorsCount")
+	}
+	return m.DelegatorsCountFunc(ctx, account)
+}
+
+func (m *API) Frontiers(ctx context.Context, account string, count int) (map[string]string, error) {
+	m.record("Frontiers", []interface{}{account, count})
+	if m.FrontiersFunc == nil {
+		var r0 map[string]string
+		return r0, notMocked("Frontiers")
+	}
+	return m.FrontiersFunc(ctx, account, count)
+}
+
+func (m *API) ValidateAccountNumber(ctx context.Context, account string) (bool, error) {
+	m.record("ValidateAccountNumber", []interface{}{account})
+	if m.ValidateAccountNumberFunc == nil {
+		var r0 bool
+		return r0, notMocked("ValidateAccountNumber")
+	}
+	return m.ValidateAccountNumberFunc(ctx, account)
+}
+
+func (m *API) Pending(ctx context.Context, account string, count int, threshold int, source bool) (interface{}, error) {
+	m.record("Pending", []interface{}{account, count, threshold, source})
+	if m.PendingFunc == nil {
+		var r0 interface{}
+		return r0, notMocked("Pending")
+	}
+	return m.PendingFunc(ctx, account, count, threshold, source)
+}
+
+func (m *API) PendingExists(ctx context.Context, hash string) (bool, error) {
+	m.record("PendingExists", []interface{}{hash})
+	if m.PendingExistsFunc == nil {
+		var r0 bool
+		return r0, notMocked("PendingExists")
+	}
+	return m.PendingExistsFunc(ctx, hash)
+}
+
+func (m *API) GetBlock(ctx context.Context, hash string) (rpc.Block, error) {
+	m.record("GetBlock", []interface{}{hash})
+	if m.GetBlockFunc == nil {
+		var r0 rpc.Block
+		return r0, notMocked("GetBlock")
+	}
+	return m.GetBlockFunc(ctx, hash)
+}
+
+func (m *API) Blocks(ctx context.Context, hashes []string) (map[string]rpc.Block, error) {
+	m.record("Blocks", []interface{}{hashes})
+	if m.BlocksFunc == nil {
+		var r0 map[string]rpc.Block
+		return r0, notMocked("Blocks")
+	}
+	return m.BlocksFunc(ctx, hashes)
+}
+
+func (m *API) BlocksInfo(ctx context.Context, hashes []string, pending bool, source bool) (map[string]*rpc.BlockInfo, error) {
+	m.record("BlocksInfo", []interface{}{hashes, pending, source})
+	if m.BlocksInfoFunc == nil {
+		var r0 map[string]*rpc.BlockInfo
+		return r0, notMocked("BlocksInfo")
+	}
+	return m.BlocksInfoFunc(ctx, hashes, pending, source)
+}
+
+func (m *API) BlockAccount(ctx context.Context, hash string) (string, error) {
+	m.record("BlockAccount", []interface{}{hash})
+	if m.BlockAccountFunc == nil {
+		var r0 string
+		return r0, notMocked("BlockAccount")
+	}
+	return m.BlockAccountFunc(ctx, hash)
+}
+
+func (m *API) BlockCount(ctx context.Context) (map[string]string, error) {
+	m.record("BlockCount", []interface{}{})
+	if m.BlockCountFunc == nil {
+		var r0 map[string]string
+		return r0, notMocked("BlockCount")
+	}
+	return m.BlockCountFunc(ctx)
+}
+
+func (m *API) BlockCountType(ctx context.Context) (map[string]string, error) {
+	m.record("BlockCountType", []interface{}{})
+	if m.BlockCountTypeFunc == nil {
+		var r0 map[string]string
+		return r0, notMocked("BlockCountType")
+	}
+	return m.BlockCountTypeFunc(ctx)
+}
+
+func (m *API) Successors(ctx context.Context, block string, count int) ([]string, error) {
+	m.record("Successors", []interface{}{block, count})
+	if m.SuccessorsFunc == nil {
+		var r0 []string
+		return r0, notMocked("Successors")
+	}
+	return m.SuccessorsFunc(ctx, block, count)
+}
+
+func (m *API) Chain(ctx context.Context, block string, count int) ([]string, error) {
+	m.record("Chain", []interface{}{block, count})
+	if m.ChainFunc == nil {
+		var r0 []string
+		return r0, notMocked("Chain")
+	}
+	return m.ChainFunc(ctx, block, count)
+}
+
+func (m *API) History(ctx context.Context, hash string, count int) ([]map[string]string, error) {
+	m.record("History", []interface{}{hash, count})
+	if m.HistoryFunc == nil {
+		var r0 []map[string]string
+		return r0, notMocked("History")
+	}
+	return m.HistoryFunc(ctx, hash, count)
+}
+
+func (m *API) AvailableSupply(ctx context.Context) (string, error) {
+	m.record("AvailableSupply", []interface{}{})
+	if m.AvailableSupplyFunc == nil {
+		var r0 string
+		return r0, notMocked("AvailableSupply")
+	}
+	return m.AvailableSupplyFunc(ctx)
+}
+
+func (m *API) FrontierCount(ctx context.Context) (int, error) {
+	m.record("FrontierCount", []interface{}{})
+	if m.FrontierCountFunc == nil {
+		var r0 int
+		return r0, notMocked("FrontierCount")
+	}
+	return m.FrontierCountFunc(ctx)
+}
+
+func (m *API) Representatives(ctx context.Context, count int, sort bool) (map[string]string, error) {
+	m.record("Representatives", []interface{}{count, sort})
+	if m.RepresentativesFunc == nil {
+		var r0 map[string]string
+		return r0, notMocked("Representatives")
+	}
+	return m.RepresentativesFunc(ctx, count, sort)
+}
+
+func (m *API) Ledger(ctx context.Context, account string, count int, representative bool, weight bool, pending bool, sorting bool) (map[string]*rpc.Account, error) {
+	m.record("Ledger", []interface{}{account, count, representative, weight, pending, sorting})
+	if m.LedgerFunc == nil {
+		var r0 map[string]*rpc.Account
+		return r0, notMocked("Ledger")
+	}
+	return m.LedgerFunc(ctx, account, count, representative, weight, pending, sorting)
+}
+
+func (m *API) UncheckedBlocks(ctx context.Context, count int) (map[string]rpc.Block, error) {
+	m.record("UncheckedBlocks", []interface{}{count})
+	if m.UncheckedBlocksFunc == nil {
+		var r0 map[string]rpc.Block
+		return r0, notMocked("UncheckedBlocks")
+	}
+	return m.UncheckedBlocksFunc(ctx, count)
+}
+
+func (m *API) GetUncheckedBlock(ctx context.Context, hash string) (rpc.Block, error) {
+	m.record("GetUncheckedBlock", []interface{}{hash})
+	if m.GetUncheckedBlockFunc == nil {
+		var r0 rpc.Block
+		return r0, notMocked("GetUncheckedBlock")
+	}
+	return m.GetUncheckedBlockFunc(ctx, hash)
+}
+
+func (m *API) UncheckedKeys(ctx context.Context, key string, count int) ([]rpc.UncheckedEntry, error) {
+	m.record("UncheckedKeys", []interface{}{key, count})
+	if m.UncheckedKeysFunc == nil {
+		var r0 []rpc.UncheckedEntry
+		return r0, notMocked("UncheckedKeys")
+	}
+	return m.UncheckedKeysFunc(ctx, key, count)
+}
+
+func (m *API) CreateWallet(ctx context.Context) (string, error) {
+	m.record("CreateWallet", []interface{}{})
+	if m.CreateWalletFunc == nil {
+		var r0 string
+		return r0, notMocked("CreateWallet")
+	}
+	return m.CreateWalletFunc(ctx)
+}
+
+func (m *API) DestroyWallet(ctx context.Context, wallet string) error {
+	m.record("DestroyWallet", []interface{}{wallet})
+	if m.DestroyWalletFunc == nil {
+		return notMocked("DestroyWallet")
+	}
+	return m.DestroyWalletFunc(ctx, wallet)
+}
+
+func (m *API) ExportWallet(ctx context.Context, wallet string) (string, error) {
+	m.record("ExportWallet", []interface{}{wallet})
+	if m.ExportWalletFunc == nil {
+		var r0 string
+		return r0, notMocked("ExportWallet")
+	}
+	return m.ExportWalletFunc(ctx, wallet)
+}
+
+func (m *API) ChangeWalletSeed(ctx context.Context, wallet string, seed string) (bool, error) {
+	m.record("ChangeWalletSeed", []interface{}{wallet, seed})
+	if m.ChangeWalletSeedFunc == nil {
+		var r0 bool
+		return r0, notMocked("ChangeWalletSeed")
+	}
+	return m.ChangeWalletSeedFunc(ctx, wallet, seed)
+}
+
+func (m *API) WalletAdd(ctx context.Context, wallet string, key string, work bool) (string, error) {
+	m.record("WalletAdd", []interface{}{wallet, key, work})
+	if m.WalletAddFunc == nil {
+		var r0 string
+		return r0, notMocked("WalletAdd")
+	}
+	return m.WalletAddFunc(ctx, wallet, key, work)
+}
+
+func (m *API) WalletContains(ctx context.Context, wallet string, account string) (bool, error) {
+	m.record("WalletContains", []interface{}{wallet, account})
+	if m.WalletContainsFunc == nil {
+		var r0 bool
+		return r0, notMocked("WalletContains")
+	}
+	return m.WalletContainsFunc(ctx, wallet, account)
+}
+
+func (m *API) CreateAccount(ctx context.Context, wallet string, work bool) (string, error) {
+	m.record("CreateAccount", []interface{}{wallet, work})
+	if m.CreateAccountFunc == nil {
+		var r0 string
+		return r0, notMocked("CreateAccount")
+	}
+	return m.CreateAccountFunc(ctx, wallet, work)
+}
+
+func (m *API) CreateAccounts(ctx context.Context, wallet string, count int, work bool) ([]string, error) {
+	m.record("CreateAccounts", []interface{}{wallet, count, work})
+	if m.CreateAccountsFunc == nil {
+		var r0 []string
+		return r0, notMocked("CreateAccounts")
+	}
+	return m.CreateAccountsFunc(ctx, wallet, count, work)
+}
+
+func (m *API) AccountList(ctx context.Context, wallet string) ([]string, error) {
+	m.record("AccountList", []interface{}{wallet})
+	if m.AccountListFunc == nil {
+		var r0 []string
+		return r0, notMocked("AccountList")
+	}
+	return m.AccountListFunc(ctx, wallet)
+}
+
+func (m *API) MoveAccounts(ctx context.Context, wallet string, source string, accounts []string) (bool, error) {
+	m.record("MoveAccounts", []interface{}{wallet, source, accounts})
+	if m.MoveAccountsFunc == nil {
+		var r0 bool
+		return r0, notMocked("MoveAccounts")
+	}
+	return m.MoveAccountsFunc(ctx, wallet, source, accounts)
+}
+
+func (m *API) RemoveAccount(ctx context.Context, wallet string, account string) (bool, error) {
+	m.record("RemoveAccount", []interface{}{wallet, account})
+	if m.RemoveAccountFunc == nil {
+		var r0 bool
+		return r0, notMocked("RemoveAccount")
+	}
+	return m.RemoveAccountFunc(ctx, wallet, account)
+}
+
+func (m *API) SetAccountRepresentative(ctx context.Context, wallet string, account string, representative string, work string) (string, error) {
+	m.record("SetAccountRepresentative", []interface{}{wallet, account, representative, work})
+	if m.SetAccountRepresentativeFunc == nil {
+		var r0 string
+		return r0, notMocked("SetAccountRepresentative")
+	}
+	return m.SetAccountRepresentativeFunc(ctx, wallet, account, representative, work)
+}
+
+func (m *API) WalletRepresentative(ctx context.Context, wallet string) (string, error) {
+	m.record("WalletRepresentative", []interface{}{wallet})
+	if m.WalletRepresentativeFunc == nil {
+		var r0 string
+		return r0, notMocked("WalletRepresentative")
+	}
+	return m.WalletRepresentativeFunc(ctx, wallet)
+}
+
+func (m *API) SetWalletRepresentative(ctx context.Context, wallet string, representative string) (bool, error) {
+	m.record("SetWalletRepresentative", []interface{}{wallet, representative})
+	if m.SetWalletRepresentativeFunc == nil {
+		var r0 bool
+		return r0, notMocked("SetWalletRepresentative")
+	}
+	return m.SetWalletRepresentativeFunc(ctx, wallet, representative)
+}
+
+func (m *API) WalletTotalBalance(ctx context.Context, wallet string) (map[string]string, error) {
+	m.record("WalletTotalBalance", []interface{}{wallet})
+	if m.WalletTotalBalanceFunc == nil {
+		var r0 map[string]string
+		return r0, notMocked("WalletTotalBalance")
+	}
+	return m.WalletTotalBalanceFunc(ctx, wallet)
+}
+
+func (m *API) WalletBalances(ctx context.Context, wallet string, threshold int) (map[string]map[string]string, error) {
+	m.record("WalletBalances", []interface{}{wallet, threshold})
+	if m.WalletBalancesFunc == nil {
+		var r0 map[string]map[string]string
+		return r0, notMocked("WalletBalances")
+	}
+	return m.WalletBalancesFunc(ctx, wallet, threshold)
+}
+
+func (m *API) WalletFrontiers(ctx context.Context, wallet string) (map[string]string, error) {
+	m.record("WalletFrontiers", []interface{}{wallet})
+	if m.WalletFrontiersFunc == nil {
+		var r0 map[string]string
+		return r0, notMocked("WalletFrontiers")
+	}
+	return m.WalletFrontiersFunc(ctx, wallet)
+}
+
+func (m *API) WalletPending(ctx context.Context, wallet string, count int, threshold int, source bool) (map[string]interface{}, error) {
+	m.record("WalletPending", []interface{}{wallet, count, threshold, source})
+	if m.WalletPendingFunc == nil {
+		var r0 map[string]interface{}
+		return r0, notMocked("WalletPending")
+	}
+	return m.WalletPendingFunc(ctx, wallet, count, threshold, source)
+}
+
+func (m *API) WalletRepublish(ctx context.Context, wallet string, count int) ([]string, error) {
+	m.record("WalletRepublish", []interface{}{wallet, count})
+	if m.WalletRepublishFunc == nil {
+		var r0 []string
+		return r0, notMocked("WalletRepublish")
+	}
+	return m.WalletRepublishFunc(ctx, wallet, count)
+}
+
+func (m *API) WalletWorkGet(ctx context.Context, wallet string) (map[string]string, error) {
+	m.record("WalletWorkGet", []interface{}{wallet})
+	if m.WalletWorkGetFunc == nil {
+		var r0 map[string]string
+		return r0, notMocked("WalletWorkGet")
+	}
+	return m.WalletWorkGetFunc(ctx, wallet)
+}
+
+func (m *API) GetWork(ctx context.Context, wallet string, account string) (string, error) {
+	m.record("GetWork", []interface{}{wallet, account})
+	if m.GetWorkFunc == nil {
+		var r0 string
+		return r0, notMocked("GetWork")
+	}
+	return m.GetWorkFunc(ctx, wallet, account)
+}
+
+func (m *API) SetWork(ctx context.Context, wallet string, account string, work string) (bool, error) {
+	m.record("SetWork", []interface{}{wallet, account, work})
+	if m.SetWorkFunc == nil {
+		var r0 bool
+		return r0, notMocked("SetWork")
+	}
+	return m.SetWorkFunc(ctx, wallet, account, work)
+}
+
+func (m *API) ChangeWalletPassword(ctx context.Context, wallet string, password string) (bool, error) {
+	m.record("ChangeWalletPassword", []interface{}{wallet, password})
+	if m.ChangeWalletPasswordFunc == nil {
+		var r0 bool
+		return r0, notMocked("ChangeWalletPassword")
+	}
+	return m.ChangeWalletPasswordFunc(ctx, wallet, password)
+}
+
+func (m *API) EnterWalletPassword(ctx context.Context, wallet string, password string) (bool, error) {
+	m.record("EnterWalletPassword", []interface{}{wallet, password})
+	if m.EnterWalletPasswordFunc == nil {
+		var r0 bool
+		return r0, notMocked("EnterWalletPassword")
+	}
+	return m.EnterWalletPasswordFunc(ctx, wallet, password)
+}
+
+func (m *API) WalletPasswordValid(ctx context.Context, wallet string, password string) (bool, error) {
+	m.record("WalletPasswordValid", []interface{}{wallet, password})
+	if m.WalletPasswordValidFunc == nil {
+		var r0 bool
+		return r0, notMocked("WalletPasswordValid")
+	}
+	return m.WalletPasswordValidFunc(ctx, wallet, password)
+}
+
+func (m *API) IsWalletLocked(ctx context.Context, wallet string) (bool, error) {
+	m.record("IsWalletLocked", []interface{}{wallet})
+	if m.IsWalletLockedFunc == nil {
+		var r0 bool
+		return r0, notMocked("IsWalletLocked")
+	}
+	return m.IsWalletLockedFunc(ctx, wallet)
+}
+
+func (m *API) Send(ctx context.Context, wallet string, source string, destination string, id string, amount int, work string) (string, error) {
+	m.record("Send", []interface{}{wallet, source, destination, id, amount, work})
+	if m.SendFunc == nil {
+		var r0 string
+		return r0, notMocked("Send")
+	}
+	return m.SendFunc(ctx, wallet, source, destination, id, amount, work)
+}
+
+func (m *API) ReceiveBlock(ctx context.Context, wallet string, account string, block string, work string) (string, error) {
+	m.record("ReceiveBlock", []interface{}{wallet, account, block, work})
+	if m.ReceiveBlockFunc == nil {
+		var r0 string
+		return r0, notMocked("ReceiveBlock")
+	}
+	return m.ReceiveBlockFunc(ctx, wallet, account, block, work)
+}
+
+func (m *API) SearchPending(ctx context.Context, wallet string) (bool, error) {
+	m.record("SearchPending", []interface{}{wallet})
+	if m.SearchPendingFunc == nil {
+		var r0 bool
+		return r0, notMocked("SearchPending")
+	}
+	return m.SearchPendingFunc(ctx, wallet)
+}
+
+func (m *API) SearchAllPending(ctx context.Context) (bool, error) {
+	m.record("SearchAllPending", []interface{}{})
+	if m.SearchAllPendingFunc == nil {
+		var r0 bool
+		return r0, notMocked("SearchAllPending")
+	}
+	return m.SearchAllPendingFunc(ctx)
+}
+
+func (m *API) GetReceiveMinimum(ctx context.Context) (string, error) {
+	m.record("GetReceiveMinimum", []interface{}{})
+	if m.GetReceiveMinimumFunc == nil {
+		var r0 string
+		return r0, notMocked("GetReceiveMinimum")
+	}
+	return m.GetReceiveMinimumFunc(ctx)
+}
+
+func (m *API) SetReceiveMinimum(ctx context.Context, amount string) (bool, error) {
+	m.record("SetReceiveMinimum", []interface{}{amount})
+	if m.SetReceiveMinimumFunc == nil {
+		var r0 bool
+		return r0, notMocked("SetReceiveMinimum")
+	}
+	return m.SetReceiveMinimumFunc(ctx, amount)
+}
+
+func (m *API) BeginPayment(ctx context.Context, wallet string) (string, error) {
+	m.record("BeginPayment", []interface{}{wallet})
+	if m.BeginPaymentFunc == nil {
+		var r0 string
+		return r0, notMocked("BeginPayment")
+	}
+	return m.BeginPaymentFunc(ctx, wallet)
+}
+
+func (m *API) InitPayment(ctx context.Context, wallet string) (string, error) {
+	m.record("InitPayment", []interface{}{wallet})
+	if m.InitPaymentFunc == nil {
+		var r0 string
+		return r0, notMocked("InitPayment")
+	}
+	return m.InitPaymentFunc(ctx, wallet)
+}
+
+func (m *API) EndPayment(ctx context.Context, wallet string, account string) error {
+	m.record("EndPayment", []interface{}{wallet, account})
+	if m.EndPaymentFunc == nil {
+		return notMocked("EndPayment")
+	}
+	return m.EndPaymentFunc(ctx, wallet, account)
+}
+
+func (m *API) WaitPayment(ctx context.Context, account string, amount string, timeout int) (string, error) {
+	m.record("WaitPayment", []interface{}{account, amount, timeout})
+	if m.WaitPaymentFunc == nil {
+		var r0 string
+		return r0, notMocked("WaitPayment")
+	}
+	return m.WaitPaymentFunc(ctx, account, amount, timeout)
+}
+
+func (m *API) CreateOpenBlock(ctx context.Context, key string, account string, representative string, source string, work string) (string, *rpc.LegacyOpenBlock, error) {
+	m.record("CreateOpenBlock", []interface{}{key, account, representative, source, work})
+	if m.CreateOpenBlockFunc == nil {
+		var r0 string
+		var r1 *rpc.LegacyOpenBlock
+		return r0, r1, notMocked("CreateOpenBlock")
+	}
+	return m.CreateOpenBlockFunc(ctx, key, account, representative, source, work)
+}
+
+func (m *API) CreateReceiveBlock(ctx context.Context, wallet string, account string, source string, previous string, work string) (string, *rpc.LegacyReceiveBlock, error) {
+	m.record("CreateReceiveBlock", []interface{}{wallet, account, source, previous, work})
+	if m.CreateReceiveBlockFunc == nil {
+		var r0 string
+		var r1 *rpc.LegacyReceiveBlock
+		return r0, r1, notMocked("CreateReceiveBlock")
+	}
+	return m.CreateReceiveBlockFunc(ctx, wallet, account, source, previous, work)
+}
+
+func (m *API) CreateSendBlock(ctx context.Context, wallet string, account string, destination string, balance string, amount string, previous string, work string) (string, *rpc.LegacySendBlock, error) {
+	m.record("CreateSendBlock", []interface{}{wallet, account, destination, balance, amount, previous, work})
+	if m.CreateSendBlockFunc == nil {
+		var r0 string
+		var r1 *rpc.LegacySendBlock
+		return r0, r1, notMocked("CreateSendBlock")
+	}
+	return m.CreateSendBlockFunc(ctx, wallet, account, destination, balance, amount, previous, work)
+}
+
+func (m *API) CreateChangeBlock(ctx context.Context, wallet string, account string, representative string, previous string, work string) (string, *rpc.LegacyChangeBlock, error) {
+	m.record("CreateChangeBlock", []interface{}{wallet, account, representative, previous, work})
+	if m.CreateChangeBlockFunc == nil {
+		var r0 string
+		var r1 *rpc.LegacyChangeBlock
+		return r0, r1, notMocked("CreateChangeBlock")
+	}
+	return m.CreateChangeBlockFunc(ctx, wallet, account, representative, previous, work)
+}
+
+func (m *API) CreateStateBlock(ctx context.Context, subtype string, req rpc.CreateStateBlockRequest) (string, *rpc.StateBlock, error) {
+	m.record("CreateStateBlock", []interface{}{subtype, req})
+	if m.CreateStateBlockFunc == nil {
+		var r0 string
+		var r1 *rpc.StateBlock
+		return r0, r1, notMocked("CreateStateBlock")
+	}
+	return m.CreateStateBlockFunc(ctx, subtype, req)
+}
+
+func (m *API) ProcessBlock(ctx context.Context, block rpc.Block, subtype string, force bool, async bool) (string, error) {
+	m.record("ProcessBlock", []interface{}{block, subtype, force, async})
+	if m.ProcessBlockFunc == nil {
+		var r0 string
+		return r0, notMocked("ProcessBlock")
+	}
+	return m.ProcessBlockFunc(ctx, block, subtype, force, async)
+}
+
+func (m *API) Republish(ctx context.Context, hash string, count int, sources int, destinations int) ([]string, error) {
+	m.record("Republish", []interface{}{hash, count, sources, destinations})
+	if m.RepublishFunc == nil {
+		var r0 []string
+		return r0, notMocked("Republish")
+	}
+	return m.RepublishFunc(ctx, hash, count, sources, destinations)
+}
+
+func (m *API) GenerateWork(ctx context.Context, hash string) (string, error) {
+	m.record("GenerateWork", []interface{}{hash})
+	if m.GenerateWorkFunc == nil {
+		var r0 string
+		return r0, notMocked("GenerateWork")
+	}
+	return m.GenerateWorkFunc(ctx, hash)
+}
+
+func (m *API) CancelWork(ctx context.Context, hash string) error {
+	m.record("CancelWork", []interface{}{hash})
+	if m.CancelWorkFunc == nil {
+		return notMocked("CancelWork")
+	}
+	return m.CancelWorkFunc(ctx, hash)
+}
+
+func (m *API) ValidateWork(ctx context.Context, work string, hash string) (bool, error) {
+	m.record("ValidateWork", []interface{}{work, hash})
+	if m.ValidateWorkFunc == nil {
+		var r0 bool
+		return r0, notMocked("ValidateWork")
+	}
+	return m.ValidateWorkFunc(ctx, work, hash)
+}
+
+func (m *API) AddWorkPeer(ctx context.Context, address string, port string) (bool, error) {
+	m.record("AddWorkPeer", []interface{}{address, port})
+	if m.AddWorkPeerFunc == nil {
+		var r0 bool
+		return r0, notMocked("AddWorkPeer")
+	}
+	return m.AddWorkPeerFunc(ctx, address, port)
+}
+
+func (m *API) GetWorkPeers(ctx context.Context) ([]string, error) {
+	m.record("GetWorkPeers", []interface{}{})
+	if m.GetWorkPeersFunc == nil {
+		var r0 []string
+		return r0, notMocked("GetWorkPeers")
+	}
+	return m.GetWorkPeersFunc(ctx)
+}
+
+func (m *API) ClearWorkPeers(ctx context.Context) (bool, error) {
+	m.record("ClearWorkPeers", []interface{}{})
+	if m.ClearWorkPeersFunc == nil {
+		var r0 bool
+		return r0, notMocked("ClearWorkPeers")
+	}
+	return m.ClearWorkPeersFunc(ctx)
+}
+
+func (m *API) Version(ctx context.Context) (map[string]string, error) {
+	m.record("Version", []interface{}{})
+	if m.VersionFunc == nil {
+		var r0 map[string]string
+		return r0, notMocked("Version")
+	}
+	return m.VersionFunc(ctx)
+}
+
+func (m *API) Capabilities(ctx context.Context) (rpc.Capabilities, error) {
+	m.record("Capabilities", []interface{}{})
+	if m.CapabilitiesFunc == nil {
+		var r0 rpc.Capabilities
+		return r0, notMocked("Capabilities")
+	}
+	return m.CapabilitiesFunc(ctx)
+}
+
+func (m *API) Peers(ctx context.Context) (map[string]string, error) {
+	m.record("Peers", []interface{}{})
+	if m.PeersFunc == nil {
+		var r0 map[string]string
+		return r0, notMocked("Peers")
+	}
+	return m.PeersFunc(ctx)
+}
+
+func (m *API) SendKeepalive(ctx context.Context, address string, port int) error {
+	m.record("SendKeepalive", []interface{}{address, port})
+	if m.SendKeepaliveFunc == nil {
+		return notMocked("SendKeepalive")
+	}
+	return m.SendKeepaliveFunc(ctx, address, port)
+}
+
+func (m *API) Bootstrap(ctx context.Context, address string, port int) (bool, error) {
+	m.record("Bootstrap", []interface{}{address, port})
+	if m.BootstrapFunc == nil {
+		var r0 bool
+		return r0, notMocked("Bootstrap")
+	}
+	return m.BootstrapFunc(ctx, address, port)
+}
+
+func (m *API) BootstrapAny(ctx context.Context) (bool, error) {
+	m.record("BootstrapAny", []interface{}{})
+	if m.BootstrapAnyFunc == nil {
+		var r0 bool
+		return r0, notMocked("BootstrapAny")
+	}
+	return m.BootstrapAnyFunc(ctx)
+}
+
+func (m *API) ClearUncheckedBlocks(ctx context.Context) (bool, error) {
+	m.record("ClearUncheckedBlocks", []interface{}{})
+	if m.ClearUncheckedBlocksFunc == nil {
+		var r0 bool
+		return r0, notMocked("ClearUncheckedBlocks")
+	}
+	return m.ClearUncheckedBlocksFunc(ctx)
+}
+
+func (m *API) DeterministicKey(ctx context.Context, seed string, index int) (map[string]string, error) {
+	m.record("DeterministicKey", []interface{}{seed, index})
+	if m.DeterministicKeyFunc == nil {
+		var r0 map[string]string
+		return r0, notMocked("DeterministicKey")
+	}
+	return m.DeterministicKeyFunc(ctx, seed, index)
+}
+
+func (m *API) KeyCreate(ctx context.Context) (map[string]string, error) {
+	m.record("KeyCreate", []interface{}{})
+	if m.KeyCreateFunc == nil {
+		var r0 map[string]string
+		return r0, notMocked("KeyCreate")
+	}
+	return m.KeyCreateFunc(ctx)
+}
+
+func (m *API) KeyExpand(ctx context.Context, key string) (map[string]string, error) {
+	m.record("KeyExpand", []interface{}{key})
+	if m.KeyExpandFunc == nil {
+		var r0 map[string]string
+		return r0, notMocked("KeyExpand")
+	}
+	return m.KeyExpandFunc(ctx, key)
+}
+
+func (m *API) Stop(ctx context.Context) (bool, error) {
+	m.record("Stop", []interface{}{})
+	if m.StopFunc == nil {
+		var r0 bool
+		return r0, notMocked("Stop")
+	}
+	return m.StopFunc(ctx)
+}
+
+func (m *API) Call(ctx context.Context, action string, params interface{}, out interface{}) error {
+	m.record("Call", []interface{}{action, params, out})
+	if m.CallFunc == nil {
+		return notMocked("Call")
+	}
+	return m.CallFunc(ctx, action, params, out)
+}
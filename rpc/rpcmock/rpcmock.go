@@ -0,0 +1,38 @@
+// Package rpcmock provides a mock of rpc.API for testing code
+// that talks to a node through the rpc package.
+package rpcmock
+
+//go:generate go run ../internal/mockgen -src .. -iface API -o mock.go
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Returned (wrapped) by methods of API whose function field is nil.
+var ErrNotMocked = errors.New("rpcmock: method not mocked")
+
+// Call is a recorded call of a mock method.
+// Args holds the arguments after the context.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Returns the calls made so far, in order.
+func (m *API) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+func (m *API) record(method string, args []interface{}) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+	m.mu.Unlock()
+}
+
+func notMocked(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotMocked, method)
+}
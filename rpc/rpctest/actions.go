@@ -0,0 +1,1769 @@
+package rpctest
+
+import (
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type handler func(l *ledger, p params) (interface{}, error)
+
+// Actions implemented by the fake node.
+var handlers = map[string]handler{
+	"account_balance":            accountBalance,
+	"account_block_count":        accountBlockCount,
+	"account_create":             accountCreate,
+	"account_get":                accountGet,
+	"account_history":            accountHistory,
+	"account_info":               accountInfo,
+	"account_key":                accountKey,
+	"account_list":               accountList,
+	"account_move":               accountMove,
+	"account_remove":             accountRemove,
+	"account_representative":     accountRepresentative,
+	"account_representative_set": accountRepresentativeSet,
+	"account_weight":             accountWeight,
+	"accounts_balances":          accountsBalances,
+	"accounts_create":            accountsCreate,
+	"accounts_frontiers":         accountsFrontiers,
+	"accounts_pending":           accountsPending,
+	"available_supply":           availableSupply,
+	"block":                      blockContents,
+	"block_account":              blockAccount,
+	"block_count":                blockCount,
+	"block_count_type":           blockCountType,
+	"block_create":               blockCreate,
+	"blocks":                     blocks,
+	"blocks_info":                blocksInfo,
+	"bootstrap":                  ignored,
+	"bootstrap_any":              ignored,
+	"chain":                      chain,
+	"delegators":                 delegators,
+	"delegators_count":           delegatorsCount,
+	"deterministic_key":          deterministicKeyAction,
+	"frontier_count":             frontierCount,
+	"frontiers":                  frontiers,
+	"history":                    history,
+	"keepalive":                  keepalive,
+	"key_create":                 keyCreate,
+	"key_expand":                 keyExpand,
+	"ledger":                     ledgerAction,
+	"password_change":            passwordChange,
+	"password_enter":             passwordEnter,
+	"password_locked":            passwordLocked,
+	"password_valid":             passwordValid,
+	"payment_begin":              paymentBegin,
+	"payment_end":                paymentEnd,
+	"payment_init":               paymentInit,
+	"payment_wait":               paymentWait,
+	"peers":                      peers,
+	"pending":                    pending,
+	"pending_exists":             pendingExists,
+	"process":                    process,
+	"receive":                    receive,
+	"receive_minimum":            receiveMinimum,
+	"receive_minimum_set":        receiveMinimumSet,
+	"representatives":            representatives,
+	"republish":                  republish,
+	"search_pending":             searchPending,
+	"search_pending_all":         ignored,
+	"send":                       send,
+	"stop":                       ignored,
+	"successors":                 successors,
+	"unchecked":                  unchecked,
+	"unchecked_clear":            uncheckedClear,
+	"unchecked_get":              uncheckedGet,
+	"unchecked_keys":             uncheckedKeys,
+	"validate_account_number":    validateAccountNumber,
+	"version":                    version,
+	"wallet_add":                 walletAdd,
+	"wallet_balance_total":       walletBalanceTotal,
+	"wallet_balances":            walletBalances,
+	"wallet_change_seed":         walletChangeSeed,
+	"wallet_contains":            walletContains,
+	"wallet_create":              walletCreate,
+	"wallet_destroy":             walletDestroy,
+	"wallet_export":              walletExport,
+	"wallet_frontiers":           walletFrontiers,
+	"wallet_pending":             walletPending,
+	"wallet_representative":      walletRepresentative,
+	"wallet_representative_set":  walletRepresentativeSet,
+	"wallet_republish":           walletRepublish,
+	"wallet_work_get":            walletWorkGet,
+	"work_cancel":                workCancel,
+	"work_generate":              workGenerate,
+	"work_get":                   workGet,
+	"work_peer_add":              workPeerAdd,
+	"work_peers":                 workPeers,
+	"work_peers_clear":           workPeersClear,
+	"work_set":                   workSet,
+	"work_validate":              workValidate,
+}
+
+// Actions nodes since V23 also answer to, and the handlers serving them.
+var receivableAliases = map[string]string{
+	"accounts_receivable": "accounts_pending",
+	"receivable":          "pending",
+	"receivable_exists":   "pending_exists",
+	"search_receivable":   "search_pending",
+	"wallet_receivable":   "wallet_pending",
+}
+
+func version(l *ledger, p params) (interface{}, error) {
+	return map[string]string{
+		"rpc_version":      "1",
+		"store_version":    "10",
+		"protocol_version": "10",
+		"node_vendor":      "Nano V" + strconv.Itoa(l.major) + "." + strconv.Itoa(l.minor),
+	}, nil
+}
+
+func peers(l *ledger, p params) (interface{}, error) {
+	return map[string]interface{}{"peers": map[string]string{}}, nil
+}
+
+func availableSupply(l *ledger, p params) (interface{}, error) {
+	return map[string]string{"available": genesisBalance.String()}, nil
+}
+
+func blockCount(l *ledger, p params) (interface{}, error) {
+	return map[string]string{
+		"count":     strconv.Itoa(len(l.blocks)),
+		"unchecked": strconv.Itoa(len(l.unchecked)),
+	}, nil
+}
+
+func blockCountType(l *ledger, p params) (interface{}, error) {
+	counts := map[string]int{"send": 0, "receive": 0, "open": 0, "change": 0, "state": 0}
+	for _, b := range l.blocks {
+		counts[b.typ]++
+	}
+
+	r := make(map[string]string, len(counts))
+	for typ, n := range counts {
+		r[typ] = strconv.Itoa(n)
+	}
+
+	return r, nil
+}
+
+func frontierCount(l *ledger, p params) (interface{}, error) {
+	return map[string]string{"count": strconv.Itoa(len(l.accounts))}, nil
+}
+
+func keyPair(private string) map[string]string {
+	public := publicKey(private)
+	account, _ := accountFromKey(public)
+
+	return map[string]string{
+		"private": private,
+		"public":  public,
+		"account": account,
+	}
+}
+
+func keyCreate(l *ledger, p params) (interface{}, error) {
+	return keyPair(randomHex(32)), nil
+}
+
+func keyExpand(l *ledger, p params) (interface{}, error) {
+	key := strings.ToUpper(p.str("key"))
+	if len(key) != 64 {
+		return nil, nodeError("Bad private key")
+	}
+
+	return keyPair(key), nil
+}
+
+func deterministicKeyAction(l *ledger, p params) (interface{}, error) {
+	index, err := p.int("index", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return keyPair(deterministicKey(strings.ToUpper(p.str("seed")), uint32(index))), nil
+}
+
+func accountGet(l *ledger, p params) (interface{}, error) {
+	a, ok := accountFromKey(strings.ToUpper(p.str("key")))
+	if !ok {
+		return nil, nodeError("Bad public key")
+	}
+
+	return map[string]string{"account": a}, nil
+}
+
+func accountKey(l *ledger, p params) (interface{}, error) {
+	key, ok := keyFromAccount(p.str("account"))
+	if !ok {
+		return nil, errBadAccount
+	}
+
+	return map[string]string{"key": key}, nil
+}
+
+func validateAccountNumber(l *ledger, p params) (interface{}, error) {
+	_, ok := keyFromAccount(p.str("account"))
+
+	return map[string]string{"valid": boolString(ok)}, nil
+}
+
+// Returns the fields of account_info and ledger entries.
+func (l *ledger) info(a *account, p params) map[string]string {
+	open := a.blocks[0]
+	r := map[string]string{
+		"frontier":                     a.frontier(),
+		"open_block":                   open,
+		"representative_block":         a.repBlock,
+		"balance":                      a.balance.String(),
+		"modified_timestamp":           strconv.FormatInt(a.modified, 10),
+		"block_count":                  strconv.Itoa(len(a.blocks)),
+		"account_version":              "0",
+		"confirmation_height":          strconv.Itoa(len(a.blocks)),
+		"confirmation_height_frontier": a.frontier(),
+	}
+
+	// The fake node confirms blocks as soon as they are added.
+	if p.flag("include_confirmed") {
+		r["confirmed_balance"] = r["balance"]
+		r["confirmed_height"] = r["confirmation_height"]
+		r["confirmed_frontier"] = r["frontier"]
+		if p.flag("representative") {
+			r["confirmed_representative"] = a.rep
+		}
+		if p.flag("pending") {
+			r["confirmed_pending"] = l.receivableAmount(a.id).String()
+		}
+	}
+
+	if p.flag("representative") {
+		r["representative"] = a.rep
+	}
+
+	if p.flag("weight") {
+		r["weight"] = l.weight(a.id).String()
+	}
+
+	if p.flag("pending") {
+		r["pending"] = l.receivableAmount(a.id).String()
+	}
+
+	return r
+}
+
+func accountInfo(l *ledger, p params) (interface{}, error) {
+	id, err := p.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	a, ok := l.accounts[id]
+	if !ok {
+		return nil, errAccountNotFound
+	}
+
+	return l.info(a, p), nil
+}
+
+func accountBalance(l *ledger, p params) (interface{}, error) {
+	id, err := p.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"balance": l.balance(id).String(),
+		"pending": l.receivableAmount(id).String(),
+	}, nil
+}
+
+func accountsBalances(l *ledger, p params) (interface{}, error) {
+	balances := make(map[string]map[string]string)
+	for _, id := range p.strs("accounts") {
+		if _, ok := keyFromAccount(id); !ok {
+			return nil, errBadAccount
+		}
+
+		balances[id] = map[string]string{
+			"balance": l.balance(id).String(),
+			"pending": l.receivableAmount(id).String(),
+		}
+	}
+
+	return map[string]interface{}{"balances": balances}, nil
+}
+
+func accountBlockCount(l *ledger, p params) (interface{}, error) {
+	id, err := p.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	a, ok := l.accounts[id]
+	if !ok {
+		return nil, errAccountNotFound
+	}
+
+	return map[string]string{"block_count": strconv.Itoa(len(a.blocks))}, nil
+}
+
+func accountRepresentative(l *ledger, p params) (interface{}, error) {
+	id, err := p.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	a, ok := l.accounts[id]
+	if !ok {
+		return nil, errAccountNotFound
+	}
+
+	return map[string]string{"representative": a.rep}, nil
+}
+
+func accountWeight(l *ledger, p params) (interface{}, error) {
+	id, err := p.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"weight": l.weight(id).String()}, nil
+}
+
+// Leaves unopened accounts out, or from V24 on lists them under errors
+// along with bad accounts.
+func accountsFrontiers(l *ledger, p params) (interface{}, error) {
+	r := make(map[string]string)
+	errs := make(map[string]string)
+	for _, id := range p.strs("accounts") {
+		if a, ok := l.accounts[id]; ok {
+			r[id] = a.frontier()
+			continue
+		}
+
+		if _, ok := keyFromAccount(id); !ok {
+			errs[id] = errBadAccount.Error()
+		} else {
+			errs[id] = errAccountNotFound.Error()
+		}
+	}
+
+	if l.major < 24 || len(errs) == 0 {
+		return map[string]interface{}{"frontiers": r}, nil
+	}
+
+	return map[string]interface{}{"frontiers": r, "errors": errs}, nil
+}
+
+// Returns the frontiers of ledger accounts starting at the account
+// param, in account order.
+func frontiers(l *ledger, p params) (interface{}, error) {
+	start, err := p.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := p.int("count", 1<<31-1)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make(map[string]string)
+	for _, id := range l.sortedAccounts(start) {
+		if len(r) >= count {
+			break
+		}
+		r[id] = l.accounts[id].frontier()
+	}
+
+	return map[string]interface{}{"frontiers": r}, nil
+}
+
+// Returns ledger accounts ordered by public key, starting at start.
+func (l *ledger) sortedAccounts(start string) []string {
+	startKey, _ := keyFromAccount(start)
+
+	ids := make([]string, 0, len(l.accounts))
+	for id, a := range l.accounts {
+		if a.key >= startKey {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return l.accounts[ids[i]].key < l.accounts[ids[j]].key
+	})
+
+	return ids
+}
+
+func ledgerAction(l *ledger, p params) (interface{}, error) {
+	start := p.str("account")
+	if start != "" {
+		if _, ok := keyFromAccount(start); !ok {
+			return nil, errBadAccount
+		}
+	}
+
+	count, err := p.int("count", 1<<31-1)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := l.sortedAccounts(start)
+	if p.flag("sorting") {
+		sort.SliceStable(ids, func(i, j int) bool {
+			return l.accounts[ids[i]].balance.Cmp(l.accounts[ids[j]].balance) > 0
+		})
+	}
+
+	r := make(map[string]map[string]string)
+	for _, id := range ids {
+		if len(r) >= count {
+			break
+		}
+		r[id] = l.info(l.accounts[id], p)
+	}
+
+	return map[string]interface{}{"accounts": r}, nil
+}
+
+func delegators(l *ledger, p params) (interface{}, error) {
+	rep, err := p.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	r := make(map[string]string)
+	for id, a := range l.accounts {
+		if a.rep == rep {
+			r[id] = a.balance.String()
+		}
+	}
+
+	return map[string]interface{}{"delegators": r}, nil
+}
+
+func delegatorsCount(l *ledger, p params) (interface{}, error) {
+	rep, err := p.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	n := 0
+	for _, a := range l.accounts {
+		if a.rep == rep {
+			n++
+		}
+	}
+
+	return map[string]string{"count": strconv.Itoa(n)}, nil
+}
+
+func representatives(l *ledger, p params) (interface{}, error) {
+	reps := make(map[string]string)
+	for _, a := range l.accounts {
+		reps[a.rep] = l.weight(a.rep).String()
+	}
+
+	return map[string]interface{}{"representatives": reps}, nil
+}
+
+// Formats the receivable blocks rs the way pending, accounts_pending
+// and wallet_pending do: a list of hashes, a map of hashes to amounts
+// with a threshold, or a map of hashes to amount and source with source.
+func formatReceivable(rs []*receivable, p params) (interface{}, error) {
+	count, err := p.int("count", 1<<31-1)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := new(big.Int)
+	if p.has("threshold") {
+		if threshold, err = p.amount("threshold"); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].hash < rs[j].hash
+	})
+
+	var filtered []*receivable
+	for _, r := range rs {
+		if len(filtered) >= count {
+			break
+		}
+		if r.amount.Cmp(threshold) >= 0 {
+			filtered = append(filtered, r)
+		}
+	}
+
+	switch {
+	case p.flag("source"):
+		m := make(map[string]map[string]string, len(filtered))
+		for _, r := range filtered {
+			m[r.hash] = map[string]string{"amount": r.amount.String(), "source": r.source}
+		}
+		return m, nil
+	case p.has("threshold"):
+		m := make(map[string]string, len(filtered))
+		for _, r := range filtered {
+			m[r.hash] = r.amount.String()
+		}
+		return m, nil
+	default:
+		hashes := make([]string, len(filtered))
+		for i, r := range filtered {
+			hashes[i] = r.hash
+		}
+		return hashes, nil
+	}
+}
+
+func pending(l *ledger, p params) (interface{}, error) {
+	id, err := p.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := formatReceivable(l.receivableOf(id), p)
+	if err != nil {
+		return nil, err
+	}
+
+	// The node returns an empty string rather than an empty list.
+	if hashes, ok := blocks.([]string); ok && len(hashes) == 0 {
+		blocks = ""
+	}
+
+	return map[string]interface{}{"blocks": blocks}, nil
+}
+
+func accountsPending(l *ledger, p params) (interface{}, error) {
+	r := make(map[string]interface{})
+	for _, id := range p.strs("accounts") {
+		if _, ok := keyFromAccount(id); !ok {
+			return nil, errBadAccount
+		}
+
+		blocks, err := formatReceivable(l.receivableOf(id), p)
+		if err != nil {
+			return nil, err
+		}
+		r[id] = blocks
+	}
+
+	return map[string]interface{}{"blocks": r}, nil
+}
+
+func walletPending(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make(map[string]interface{})
+	for _, id := range w.accounts {
+		rs := l.receivableOf(id)
+		if len(rs) == 0 {
+			continue
+		}
+
+		blocks, err := formatReceivable(rs, p)
+		if err != nil {
+			return nil, err
+		}
+		r[id] = blocks
+	}
+
+	return map[string]interface{}{"blocks": r}, nil
+}
+
+func pendingExists(l *ledger, p params) (interface{}, error) {
+	hash, err := p.hash("hash")
+	if err != nil {
+		return nil, err
+	}
+
+	_, ok := l.receivable[hash]
+
+	return map[string]string{"exists": boolString(ok)}, nil
+}
+
+func (p params) block(l *ledger) (*block, error) {
+	hash, err := p.hash("hash")
+	if err != nil {
+		return nil, err
+	}
+
+	b, ok := l.blocks[hash]
+	if !ok {
+		return nil, errBlockNotFound
+	}
+
+	return b, nil
+}
+
+func blockContents(l *ledger, p params) (interface{}, error) {
+	b, err := p.block(l)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"contents": b.contents()}, nil
+}
+
+func blockAccount(l *ledger, p params) (interface{}, error) {
+	b, err := p.block(l)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"account": b.account}, nil
+}
+
+func blocks(l *ledger, p params) (interface{}, error) {
+	r := make(map[string]string)
+	for _, hash := range p.strs("hashes") {
+		b, ok := l.blocks[strings.ToUpper(hash)]
+		if !ok {
+			return nil, errBlockNotFound
+		}
+		r[b.hash] = b.contents()
+	}
+
+	return map[string]interface{}{"blocks": r}, nil
+}
+
+func blocksInfo(l *ledger, p params) (interface{}, error) {
+	r := make(map[string]map[string]string)
+	for _, hash := range p.strs("hashes") {
+		b, ok := l.blocks[strings.ToUpper(hash)]
+		if !ok {
+			return nil, errBlockNotFound
+		}
+
+		// Blocks of the ledger are confirmed, and get a successor
+		// once the account builds on them.
+		successor := strings.Repeat("0", 64)
+		if a, ok := l.accounts[b.account]; ok && b.height < len(a.blocks) {
+			successor = a.blocks[b.height]
+		}
+
+		info := map[string]string{
+			"block_account":   b.account,
+			"amount":          b.amount.String(),
+			"balance":         b.balance.String(),
+			"height":          strconv.Itoa(b.height),
+			"local_timestamp": strconv.FormatInt(b.timestamp, 10),
+			"successor":       successor,
+			"confirmed":       "true",
+			"contents":        b.contents(),
+		}
+
+		if p.flag("pending") {
+			_, ok := l.receivable[b.hash]
+			info["pending"] = boolString(ok)
+		}
+
+		if p.flag("source") {
+			info["source_account"] = "0"
+			if k := b.kind(); k == "receive" || k == "open" {
+				info["source_account"], _ = l.counterparty(b)
+			}
+		}
+
+		r[b.hash] = info
+	}
+
+	return map[string]interface{}{"blocks": r}, nil
+}
+
+// Creates a state block, the only kind current nodes create, signed with
+// the key param or the key of account in wallet. The block only enters
+// the ledger once it is processed.
+func blockCreate(l *ledger, p params) (interface{}, error) {
+	if p.str("type") != "state" {
+		return nil, nodeError("Invalid block type")
+	}
+
+	var id string
+	if p.has("key") {
+		var ok bool
+		if id, ok = accountFromKey(publicKey(strings.ToUpper(p.str("key")))); !ok {
+			return nil, nodeError("Bad private key")
+		}
+	} else {
+		w, err := p.unlockedWallet(l)
+		if err != nil {
+			return nil, err
+		}
+
+		if id, err = p.account("account"); err != nil {
+			return nil, err
+		}
+
+		if !w.contains(id) {
+			return nil, errNotInWallet
+		}
+	}
+
+	previous := zeroHash
+	if s := p.str("previous"); s != "0" {
+		var err error
+		if previous, err = p.hash("previous"); err != nil {
+			return nil, err
+		}
+	}
+
+	rep, err := p.account("representative")
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := p.amount("balance")
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := p.link()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &block{
+		typ:            "state",
+		account:        id,
+		previous:       previous,
+		representative: rep,
+		balance:        balance,
+		link:           link,
+		work:           strings.ToLower(p.str("work")),
+		signature:      randomHex(64),
+	}
+	if b.work == "" {
+		b.work = strings.ToLower(randomHex(8))
+	}
+	b.hash = blockHash(b.account, b.previous, b.representative, b.balance.String(), b.link)
+
+	if p.flag("json_block") {
+		return map[string]interface{}{"hash": b.hash, "block": b.fields()}, nil
+	}
+
+	return map[string]string{"hash": b.hash, "block": b.contents()}, nil
+}
+
+// Returns the link param as a hex key, accepting accounts and hashes.
+func (p params) link() (string, error) {
+	s := p.str("link")
+	if key, ok := keyFromAccount(s); ok {
+		return key, nil
+	}
+
+	if s == "0" {
+		return zeroHash, nil
+	}
+
+	if len(s) != 64 {
+		return "", errBadLink
+	}
+
+	return strings.ToUpper(s), nil
+}
+
+// Processes a state block, given as stringified json or, with
+// json_block, as an object. Async processing only reports that
+// it started, whatever the outcome.
+func process(l *ledger, p params) (interface{}, error) {
+	var fields map[string]interface{}
+	switch v := p["block"].(type) {
+	case string:
+		if err := json.Unmarshal([]byte(v), &fields); err != nil {
+			return nil, errBadBlock
+		}
+	case map[string]interface{}:
+		if !p.flag("json_block") {
+			return nil, errBadBlock
+		}
+		fields = v
+	default:
+		return nil, errBadBlock
+	}
+
+	bp := params(fields)
+	if bp.str("type") != "state" {
+		return nil, errBadBlock
+	}
+
+	id, err := bp.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := bp.hash("previous")
+	if err != nil {
+		return nil, err
+	}
+
+	rep, err := bp.account("representative")
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := bp.amount("balance")
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := bp.link()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &block{
+		typ:            "state",
+		account:        id,
+		previous:       previous,
+		representative: rep,
+		balance:        balance,
+		link:           link,
+		work:           bp.str("work"),
+		signature:      bp.str("signature"),
+	}
+	b.hash = blockHash(b.account, b.previous, b.representative, b.balance.String(), b.link)
+
+	err = l.process(b, p.str("subtype"), p.flag("force"))
+	if err == errGapPrevious {
+		b.timestamp = time.Now().Unix()
+		l.unchecked[b.hash] = b
+	}
+
+	if p.flag("async") {
+		return map[string]string{"started": "1"}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"hash": b.hash}, nil
+}
+
+// Walks the chain of the block param backwards (or forwards if forward
+// is set) up to count blocks.
+func walk(l *ledger, p params, forward bool) (interface{}, error) {
+	b, err := p.hash("block")
+	if err != nil {
+		return nil, err
+	}
+
+	start, ok := l.blocks[b]
+	if !ok {
+		return nil, errBlockNotFound
+	}
+
+	count, err := p.int("count", 1<<31-1)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := l.accounts[start.account].blocks
+	var hashes []string
+	for i := start.height - 1; i >= 0 && i < len(chain) && len(hashes) < count; {
+		hashes = append(hashes, chain[i])
+		if forward {
+			i++
+		} else {
+			i--
+		}
+	}
+
+	return map[string]interface{}{"blocks": hashes}, nil
+}
+
+func chain(l *ledger, p params) (interface{}, error) {
+	return walk(l, p, false)
+}
+
+func successors(l *ledger, p params) (interface{}, error) {
+	return walk(l, p, true)
+}
+
+// Returns history entries for the blocks of chain ending at height,
+// newest first.
+func (l *ledger) historyOf(chain []string, height, count int) ([]map[string]string, string) {
+	var entries []map[string]string
+	i := height - 1
+	for ; i >= 0 && len(entries) < count; i-- {
+		b := l.blocks[chain[i]]
+		typ := b.kind()
+		if typ == "change" || typ == "epoch" {
+			continue
+		}
+
+		if typ == "open" {
+			typ = "receive"
+		}
+
+		account, amount := l.counterparty(b)
+		entries = append(entries, map[string]string{
+			"type":            typ,
+			"account":         account,
+			"amount":          amount.String(),
+			"hash":            b.hash,
+			"local_timestamp": strconv.FormatInt(b.timestamp, 10),
+			"height":          strconv.Itoa(b.height),
+		})
+	}
+
+	var previous string
+	if i >= 0 {
+		previous = chain[i]
+	}
+
+	return entries, previous
+}
+
+func history(l *ledger, p params) (interface{}, error) {
+	b, err := p.block(l)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := p.int("count", 1<<31-1)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, _ := l.historyOf(l.accounts[b.account].blocks, b.height, count)
+
+	return map[string]interface{}{"history": entries}, nil
+}
+
+func accountHistory(l *ledger, p params) (interface{}, error) {
+	id, err := p.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := p.int("count", 1<<31-1)
+	if err != nil {
+		return nil, err
+	}
+
+	a, ok := l.accounts[id]
+	if !ok {
+		return map[string]interface{}{"account": id, "history": ""}, nil
+	}
+
+	height := len(a.blocks)
+	if p.has("head") {
+		head, err := p.hash("head")
+		if err != nil {
+			return nil, err
+		}
+
+		b, ok := l.blocks[head]
+		if !ok || b.account != id {
+			return nil, errBlockNotFound
+		}
+		height = b.height
+	}
+
+	entries, previous := l.historyOf(a.blocks, height, count)
+	r := map[string]interface{}{"account": id, "history": entries}
+	if previous != "" {
+		r["previous"] = previous
+	}
+
+	return r, nil
+}
+
+func unchecked(l *ledger, p params) (interface{}, error) {
+	count, err := p.int("count", 1<<31-1)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make(map[string]interface{})
+	for _, b := range l.sortedUnchecked() {
+		if len(r) >= count {
+			break
+		}
+		r[b.hash] = formatBlock(b, p)
+	}
+
+	if len(r) == 0 {
+		return map[string]string{"blocks": ""}, nil
+	}
+
+	return map[string]interface{}{"blocks": r}, nil
+}
+
+// Returns the unchecked blocks keyed by the block they wait for,
+// starting at the key param, in key order.
+func uncheckedKeys(l *ledger, p params) (interface{}, error) {
+	key, err := p.hash("key")
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := p.int("count", 1<<31-1)
+	if err != nil {
+		return nil, err
+	}
+
+	var r []map[string]interface{}
+	for _, b := range l.sortedUnchecked() {
+		if len(r) >= count {
+			break
+		}
+		if b.previous < key {
+			continue
+		}
+
+		r = append(r, map[string]interface{}{
+			"key":                b.previous,
+			"hash":               b.hash,
+			"modified_timestamp": strconv.FormatInt(b.timestamp, 10),
+			"contents":           formatBlock(b, p),
+		})
+	}
+
+	if len(r) == 0 {
+		return map[string]string{"unchecked": ""}, nil
+	}
+
+	return map[string]interface{}{"unchecked": r}, nil
+}
+
+// Returns the unchecked blocks ordered by the block
+// they wait for, then by hash.
+func (l *ledger) sortedUnchecked() []*block {
+	r := make([]*block, 0, len(l.unchecked))
+	for _, b := range l.unchecked {
+		r = append(r, b)
+	}
+
+	sort.Slice(r, func(i, j int) bool {
+		if r[i].previous != r[j].previous {
+			return r[i].previous < r[j].previous
+		}
+		return r[i].hash < r[j].hash
+	})
+
+	return r
+}
+
+// Returns b as stringified json or, with json_block, as an object.
+func formatBlock(b *block, p params) interface{} {
+	if p.flag("json_block") {
+		return b.fields()
+	}
+
+	return b.contents()
+}
+
+func workGenerate(l *ledger, p params) (interface{}, error) {
+	if _, err := p.hash("hash"); err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"work": strings.ToLower(randomHex(8))}, nil
+}
+
+func workValidate(l *ledger, p params) (interface{}, error) {
+	if _, err := p.hash("hash"); err != nil {
+		return nil, err
+	}
+
+	work := p.str("work")
+
+	return map[string]string{"valid": boolString(len(work) == 16)}, nil
+}
+
+func walletCreate(l *ledger, p params) (interface{}, error) {
+	w := &wallet{
+		id:   randomHex(32),
+		seed: randomHex(32),
+		keys: make(map[string]string),
+		rep:  l.genesis,
+		work: make(map[string]string),
+	}
+	l.wallets[w.id] = w
+
+	return map[string]string{"wallet": w.id}, nil
+}
+
+func walletDestroy(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+	delete(l.wallets, w.id)
+
+	return map[string]string{}, nil
+}
+
+func walletExport(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]string, len(w.keys)+1)
+	keys["0000000000000000000000000000000000000000000000000000000000000001"] = w.seed
+	for id, private := range w.keys {
+		pub, _ := keyFromAccount(id)
+		keys[pub] = private
+	}
+
+	raw, _ := json.MarshalIndent(keys, "", "    ")
+
+	return map[string]string{"json": string(raw) + "\n"}, nil
+}
+
+func walletChangeSeed(l *ledger, p params) (interface{}, error) {
+	w, err := p.unlockedWallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	seed := strings.ToUpper(p.str("seed"))
+	if len(seed) != 64 {
+		return nil, nodeError("Bad seed")
+	}
+
+	w.seed = seed
+	w.index = 0
+	w.accounts = nil
+	w.keys = make(map[string]string)
+
+	return map[string]string{"success": ""}, nil
+}
+
+func walletContains(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := p.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"exists": boolString(w.contains(id))}, nil
+}
+
+func walletAdd(l *ledger, p params) (interface{}, error) {
+	w, err := p.unlockedWallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	key := strings.ToUpper(p.str("key"))
+	if len(key) != 64 {
+		return nil, nodeError("Bad private key")
+	}
+
+	return map[string]string{"account": w.add(key)}, nil
+}
+
+func accountCreate(l *ledger, p params) (interface{}, error) {
+	w, err := p.unlockedWallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	id := w.add(deterministicKey(w.seed, w.index))
+	w.index++
+
+	return map[string]string{"account": id}, nil
+}
+
+func accountsCreate(l *ledger, p params) (interface{}, error) {
+	w, err := p.unlockedWallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := p.int("count", 0)
+	if err != nil || count < 1 {
+		return nil, nodeError("Invalid count limit")
+	}
+
+	accounts := make([]string, count)
+	for i := range accounts {
+		accounts[i] = w.add(deterministicKey(w.seed, w.index))
+		w.index++
+	}
+
+	return map[string]interface{}{"accounts": accounts}, nil
+}
+
+func accountList(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := append([]string{}, w.accounts...)
+
+	return map[string]interface{}{"accounts": accounts}, nil
+}
+
+func accountRemove(l *ledger, p params) (interface{}, error) {
+	w, err := p.unlockedWallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := p.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	if !w.contains(id) {
+		return nil, errNotInWallet
+	}
+	w.remove(id)
+
+	return map[string]string{"removed": "1"}, nil
+}
+
+func accountMove(l *ledger, p params) (interface{}, error) {
+	w, err := p.unlockedWallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	source, ok := l.wallets[strings.ToUpper(p.str("source"))]
+	if !ok {
+		return nil, errWalletNotFound
+	}
+
+	for _, id := range p.strs("accounts") {
+		private, ok := source.keys[id]
+		if !ok {
+			return nil, errNotInWallet
+		}
+		source.remove(id)
+		w.add(private)
+	}
+
+	return map[string]string{"moved": "1"}, nil
+}
+
+func walletRepresentative(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"representative": w.rep}, nil
+}
+
+func walletRepresentativeSet(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	rep, err := p.account("representative")
+	if err != nil {
+		return nil, err
+	}
+	w.rep = rep
+
+	return map[string]string{"set": "1"}, nil
+}
+
+func accountRepresentativeSet(l *ledger, p params) (interface{}, error) {
+	w, err := p.unlockedWallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := p.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	if !w.contains(id) {
+		return nil, errNotInWallet
+	}
+
+	rep, err := p.account("representative")
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := l.change(id, rep)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"block": b.hash}, nil
+}
+
+func walletBalances(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := new(big.Int)
+	if p.has("threshold") {
+		if threshold, err = p.amount("threshold"); err != nil {
+			return nil, err
+		}
+	}
+
+	balances := make(map[string]map[string]string)
+	for _, id := range w.accounts {
+		balance := l.balance(id)
+		if balance.Cmp(threshold) < 0 {
+			continue
+		}
+
+		balances[id] = map[string]string{
+			"balance": balance.String(),
+			"pending": l.receivableAmount(id).String(),
+		}
+	}
+
+	return map[string]interface{}{"balances": balances}, nil
+}
+
+func walletBalanceTotal(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, receivable := new(big.Int), new(big.Int)
+	for _, id := range w.accounts {
+		balance.Add(balance, l.balance(id))
+		receivable.Add(receivable, l.receivableAmount(id))
+	}
+
+	return map[string]string{
+		"balance": balance.String(),
+		"pending": receivable.String(),
+	}, nil
+}
+
+func walletFrontiers(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make(map[string]string)
+	for _, id := range w.accounts {
+		if a, ok := l.accounts[id]; ok {
+			r[id] = a.frontier()
+		}
+	}
+
+	return map[string]interface{}{"frontiers": r}, nil
+}
+
+func searchPending(l *ledger, p params) (interface{}, error) {
+	if _, err := p.wallet(l); err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"started": "1"}, nil
+}
+
+func send(l *ledger, p params) (interface{}, error) {
+	w, err := p.unlockedWallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := p.account("source")
+	if err != nil {
+		return nil, err
+	}
+
+	destination, err := p.account("destination")
+	if err != nil {
+		return nil, nodeError("Bad destination account")
+	}
+
+	amount, err := p.amount("amount")
+	if err != nil {
+		return nil, err
+	}
+
+	if !w.contains(source) {
+		return nil, errNotInWallet
+	}
+
+	// Sends with a known id return the block of the first send.
+	id := p.str("id")
+	if hash, ok := l.sendIDs[id]; ok && id != "" {
+		return map[string]string{"block": hash}, nil
+	}
+
+	b, err := l.send(source, destination, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if id != "" {
+		l.sendIDs[id] = b.hash
+	}
+
+	return map[string]string{"block": b.hash}, nil
+}
+
+func receive(l *ledger, p params) (interface{}, error) {
+	w, err := p.unlockedWallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := p.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	if !w.contains(id) {
+		return nil, errNotInWallet
+	}
+
+	hash, err := p.hash("block")
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := l.receive(id, hash, w.rep)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"block": b.hash}, nil
+}
+
+func passwordEnter(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	valid := p.str("password") == w.password
+	if valid {
+		w.locked = false
+	}
+
+	return map[string]string{"valid": boolString(valid)}, nil
+}
+
+func passwordValid(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"valid": boolString(!w.locked)}, nil
+}
+
+func passwordLocked(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"locked": boolString(w.locked)}, nil
+}
+
+func passwordChange(l *ledger, p params) (interface{}, error) {
+	w, err := p.unlockedWallet(l)
+	if err != nil {
+		return nil, err
+	}
+	w.password = p.str("password")
+
+	return map[string]string{"changed": "1"}, nil
+}
+
+func (p params) walletAccount(l *ledger) (*wallet, string, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, "", err
+	}
+
+	id, err := p.account("account")
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !w.contains(id) {
+		return nil, "", errNotInWallet
+	}
+
+	return w, id, nil
+}
+
+func workGet(l *ledger, p params) (interface{}, error) {
+	w, id, err := p.walletAccount(l)
+	if err != nil {
+		return nil, err
+	}
+
+	work, ok := w.work[id]
+	if !ok {
+		work = strings.ToLower(randomHex(8))
+		w.work[id] = work
+	}
+
+	return map[string]string{"work": work}, nil
+}
+
+func workSet(l *ledger, p params) (interface{}, error) {
+	w, id, err := p.walletAccount(l)
+	if err != nil {
+		return nil, err
+	}
+	w.work[id] = p.str("work")
+
+	return map[string]string{"success": ""}, nil
+}
+
+func receiveMinimum(l *ledger, p params) (interface{}, error) {
+	return map[string]string{"amount": l.receiveMinimum.String()}, nil
+}
+
+func receiveMinimumSet(l *ledger, p params) (interface{}, error) {
+	amount, err := p.amount("amount")
+	if err != nil {
+		return nil, err
+	}
+	l.receiveMinimum = amount
+
+	return map[string]string{"success": ""}, nil
+}
+
+func uncheckedGet(l *ledger, p params) (interface{}, error) {
+	hash, err := p.hash("hash")
+	if err != nil {
+		return nil, err
+	}
+
+	b, ok := l.unchecked[hash]
+	if !ok {
+		return nil, errUncheckedNotFound
+	}
+
+	return map[string]interface{}{
+		"modified_timestamp": strconv.FormatInt(b.timestamp, 10),
+		"contents":           formatBlock(b, p),
+	}, nil
+}
+
+func uncheckedClear(l *ledger, p params) (interface{}, error) {
+	l.unchecked = make(map[string]*block)
+
+	return map[string]string{"success": ""}, nil
+}
+
+// Serves actions acting on the network or the node itself,
+// which the fake node has neither of. They just succeed.
+func ignored(l *ledger, p params) (interface{}, error) {
+	return map[string]string{"success": ""}, nil
+}
+
+func keepalive(l *ledger, p params) (interface{}, error) {
+	if p.str("address") == "" {
+		return nil, nodeError("Invalid address")
+	}
+
+	if _, err := p.int("port", 0); err != nil {
+		return nil, nodeError("Invalid port")
+	}
+
+	return map[string]string{"started": "1"}, nil
+}
+
+func workCancel(l *ledger, p params) (interface{}, error) {
+	if _, err := p.hash("hash"); err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"success": ""}, nil
+}
+
+func workPeerAdd(l *ledger, p params) (interface{}, error) {
+	address := p.str("address")
+	if address == "" {
+		return nil, nodeError("Invalid address")
+	}
+
+	port, err := p.int("port", 0)
+	if err != nil {
+		return nil, nodeError("Invalid port")
+	}
+	l.workPeers = append(l.workPeers, address+":"+strconv.Itoa(port))
+
+	return map[string]string{"success": ""}, nil
+}
+
+func workPeers(l *ledger, p params) (interface{}, error) {
+	if len(l.workPeers) == 0 {
+		return map[string]string{"work_peers": ""}, nil
+	}
+
+	return map[string]interface{}{"work_peers": l.workPeers}, nil
+}
+
+func workPeersClear(l *ledger, p params) (interface{}, error) {
+	l.workPeers = nil
+
+	return map[string]string{"success": ""}, nil
+}
+
+// Republishes the block param and up to count-1 of its successors.
+func republish(l *ledger, p params) (interface{}, error) {
+	b, err := p.block(l)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := p.int("count", 1024)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := l.accounts[b.account].blocks
+	end := min(b.height-1+count, len(chain))
+
+	return map[string]interface{}{"success": "", "blocks": chain[b.height-1 : end]}, nil
+}
+
+// Republishes the last count blocks of every account of the wallet.
+func walletRepublish(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := p.int("count", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := []string{}
+	for _, id := range w.accounts {
+		if a, ok := l.accounts[id]; ok {
+			hashes = append(hashes, a.blocks[max(len(a.blocks)-count, 0):]...)
+		}
+	}
+
+	return map[string]interface{}{"blocks": hashes}, nil
+}
+
+func walletWorkGet(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	works := make(map[string]string, len(w.accounts))
+	for _, id := range w.accounts {
+		work, ok := w.work[id]
+		if !ok {
+			work = strings.ToLower(randomHex(8))
+			w.work[id] = work
+		}
+		works[id] = work
+	}
+
+	return map[string]interface{}{"works": works}, nil
+}
+
+// Returns an account of the wallet with a zero balance for receiving
+// a payment, creating one if there is none.
+func paymentBegin(l *ledger, p params) (interface{}, error) {
+	w, err := p.unlockedWallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range w.accounts {
+		if l.payments[id] {
+			continue
+		}
+
+		if a, ok := l.accounts[id]; ok && a.balance.Sign() != 0 {
+			continue
+		}
+
+		l.payments[id] = true
+		return map[string]string{"account": id}, nil
+	}
+
+	id := w.add(deterministicKey(w.seed, w.index))
+	w.index++
+	l.payments[id] = true
+
+	return map[string]string{"account": id}, nil
+}
+
+func paymentInit(l *ledger, p params) (interface{}, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	if w.locked {
+		return map[string]string{"status": "Transaction wallet locked"}, nil
+	}
+
+	return map[string]string{"status": "Ready"}, nil
+}
+
+func paymentEnd(l *ledger, p params) (interface{}, error) {
+	_, id, err := p.walletAccount(l)
+	if err != nil {
+		return nil, err
+	}
+
+	if a, ok := l.accounts[id]; ok && a.balance.Sign() != 0 {
+		return nil, nodeError("Account has non-zero balance")
+	}
+	delete(l.payments, id)
+
+	return map[string]string{}, nil
+}
+
+// Reports whether the account holds amount. Unlike a real node the
+// fake node answers at once instead of waiting up to timeout.
+func paymentWait(l *ledger, p params) (interface{}, error) {
+	id, err := p.account("account")
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := p.amount("amount")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = p.int("timeout", 0); err != nil {
+		return nil, nodeError("Bad timeout number")
+	}
+
+	if a, ok := l.accounts[id]; ok && a.balance.Cmp(amount) >= 0 {
+		return map[string]string{"status": "success"}, nil
+	}
+
+	return map[string]string{"status": "nothing"}, nil
+}
@@ -0,0 +1,201 @@
+package rpctest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/s1na/nano-go/rpc"
+)
+
+var (
+	ErrUnmatchedInteraction = errors.New("rpctest: no recorded interaction matches request")
+	ErrUnusedInteractions   = errors.New("rpctest: recorded interactions were not replayed")
+)
+
+// Interaction is a single recorded request and the raw response of the node.
+// Secrets are scrubbed from both before they are written.
+type Interaction struct {
+	Action   string          `json:"action"`
+	Request  interface{}     `json:"request"`
+	Response json.RawMessage `json:"response"`
+}
+
+// Cassette is the file format written by Recorder and read by Replayer.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder captures every call made by a client into a cassette file.
+type Recorder struct {
+	path string
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// Returns a recorder writing to path on Save.
+func NewRecorder(path string) *Recorder {
+	return &Recorder{path: path}
+}
+
+// Returns an option installing the recorder on a client.
+func (r *Recorder) Option() rpc.Option {
+	return rpc.WithTransportWrapper(r.Wrap)
+}
+
+// Returns a transport recording every request sent through next that
+// got a response from the node, including node errors. Requests are
+// recorded as sent, after interceptors changed them, which is what a
+// Replayer gets to match. Streamed responses are read in full.
+func (r *Recorder) Wrap(next rpc.Transport) rpc.Transport {
+	return &recordingTransport{recorder: r, next: next}
+}
+
+type recordingTransport struct {
+	recorder *Recorder
+	next     rpc.Transport
+}
+
+func (t *recordingTransport) RoundTrip(ctx context.Context, action string, request []byte) ([]byte, error) {
+	raw, err := t.next.RoundTrip(ctx, action, request)
+	if raw == nil {
+		return raw, err
+	}
+
+	scrubbed, scrubErr := scrubRequest(action, request)
+	if scrubErr != nil {
+		return raw, err
+	}
+
+	response := json.RawMessage(raw)
+	var decoded interface{}
+	if json.Unmarshal(raw, &decoded) == nil {
+		if redacted, mErr := json.Marshal(rpc.Redact(action, decoded)); mErr == nil {
+			response = redacted
+		}
+	}
+
+	r := t.recorder
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Action:   action,
+		Request:  scrubbed,
+		Response: response,
+	})
+	r.mu.Unlock()
+
+	return raw, err
+}
+
+// Writes the recorded interactions to the cassette file.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	raw, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, append(raw, '\n'), 0644)
+}
+
+// Replayer serves responses from a cassette instead of a node. Requests
+// are matched by action and payload, in recorded order for repeated ones.
+// The id of a send is ignored, as it is new for every send not retried.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// Loads the cassette at path.
+func LoadCassette(path string) (*Replayer, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Cassette
+	if err = json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("rpctest: reading cassette %s: %w", path, err)
+	}
+
+	return &Replayer{
+		interactions: c.Interactions,
+		used:         make([]bool, len(c.Interactions)),
+	}, nil
+}
+
+// Returns an option making a client replay from the cassette.
+func (r *Replayer) Option() rpc.Option {
+	return rpc.WithTransport(r)
+}
+
+// Returns the recorded response of the first unused interaction
+// matching the request, or ErrUnmatchedInteraction.
+func (r *Replayer) RoundTrip(ctx context.Context, action string, request []byte) ([]byte, error) {
+	want, err := scrubRequest(action, request)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.interactions {
+		if r.used[i] || in.Action != action || !reflect.DeepEqual(in.Request, want) {
+			continue
+		}
+
+		r.used[i] = true
+
+		return in.Response, nil
+	}
+
+	got, _ := json.Marshal(want)
+
+	return nil, fmt.Errorf("%w: %s %s", ErrUnmatchedInteraction, action, got)
+}
+
+// Returns ErrUnusedInteractions listing every interaction
+// that was recorded but never replayed.
+func (r *Replayer) Verify() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []string
+	for i, in := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, in.Action)
+		}
+	}
+
+	if len(unused) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnusedInteractions, strings.Join(unused, ", "))
+	}
+
+	return nil
+}
+
+// Decodes request, drops the action and the id of a send
+// and scrubs secrets, so it compares equal after a round trip.
+func scrubRequest(action string, request []byte) (interface{}, error) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(request, &payload); err != nil {
+		return nil, err
+	}
+
+	delete(payload, "action")
+	if action == "send" {
+		delete(payload, "id")
+	}
+
+	return rpc.Redact(action, payload), nil
+}
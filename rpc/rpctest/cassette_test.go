@@ -0,0 +1,232 @@
+package rpctest_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+// Runs session against a node while recording it, then again against
+// the cassette, and checks every interaction was replayed. Both clients
+// get the options returned by opts.
+func recordAndReplay(t *testing.T, n *rpctest.Node, session func(c *rpc.Client) error, opts func() []rpc.Option) *rpctest.Replayer {
+	t.Helper()
+
+	if opts == nil {
+		opts = func() []rpc.Option { return nil }
+	}
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := rpctest.NewRecorder(path)
+
+	if err := session(n.Client(append(opts(), recorder.Option())...)); err != nil {
+		t.Fatalf("recording: %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer, err := rpctest.LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := rpc.NewClient("http://replay.invalid", append(opts(), replayer.Option())...)
+	if err = session(c); err != nil {
+		t.Fatalf("replaying: %v", err)
+	}
+	if err = replayer.Verify(); err != nil {
+		t.Error(err)
+	}
+
+	return replayer
+}
+
+func TestCassetteSend(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	ctx := context.Background()
+	var hash, sent string
+
+	recordAndReplay(t, n, func(c *rpc.Client) error {
+		wallet, err := c.CreateWallet(ctx)
+		if err != nil {
+			return err
+		}
+
+		account, err := c.CreateAccount(ctx, wallet, false)
+		if err != nil {
+			return err
+		}
+
+		// Only funded while recording, the replay reuses the hash.
+		if hash == "" {
+			if hash, err = n.Fund(account, "1000"); err != nil {
+				return err
+			}
+		}
+
+		if _, err = c.ReceiveBlock(ctx, wallet, account, hash, ""); err != nil {
+			return err
+		}
+
+		// Every run sends with an id of its own.
+		block, err := c.Send(ctx, wallet, account, n.Genesis(), rpc.NewSendID(), 400, "")
+		if err != nil {
+			return err
+		}
+
+		if sent == "" {
+			sent = block
+		} else if block != sent {
+			t.Errorf("replayed send returned %s, want %s", block, sent)
+		}
+
+		return nil
+	}, nil)
+}
+
+func TestCassetteCompatibility(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	n.SetVersion(23, 0)
+
+	ctx := context.Background()
+	account := n.Genesis()
+
+	replayer := recordAndReplay(t, n, func(c *rpc.Client) error {
+		_, err := c.Pending(ctx, account, 10, 0, false)
+		return err
+	}, func() []rpc.Option {
+		return []rpc.Option{rpc.WithCompatibility()}
+	})
+
+	// The cassette holds the renamed action.
+	c := rpc.NewClient("http://replay.invalid", replayer.Option())
+	if _, err := c.Pending(ctx, account, 10, 0, false); !errors.Is(err, rpctest.ErrUnmatchedInteraction) || !strings.Contains(err.Error(), "pending") {
+		t.Errorf("pending replayed without compatibility returned %v, want ErrUnmatchedInteraction", err)
+	}
+}
+
+func TestCassetteCache(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	ctx := context.Background()
+	c := n.Client()
+
+	wallet, err := c.CreateWallet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := c.CreateAccount(ctx, wallet, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var hashes []string
+	for i := 0; i < 2; i++ {
+		hash, err := n.Fund(account, "1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	// The second call asks the node for the uncached hash only.
+	recordAndReplay(t, n, func(c *rpc.Client) error {
+		if _, err := c.Blocks(ctx, hashes[:1]); err != nil {
+			return err
+		}
+
+		blocks, err := c.Blocks(ctx, hashes)
+		if err == nil && len(blocks) != len(hashes) {
+			t.Errorf("got %d blocks, want %d", len(blocks), len(hashes))
+		}
+
+		return err
+	}, func() []rpc.Option {
+		return []rpc.Option{rpc.WithCache(rpc.NewCache(rpc.NewLRUCache(16), time.Minute))}
+	})
+}
+
+func TestCassetteMismatch(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := rpctest.NewRecorder(path)
+	ctx := context.Background()
+
+	c := n.Client(recorder.Option())
+	if _, err := c.BlockCount(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AccountKey(ctx, "nano_1111"); !errors.Is(err, rpc.ErrBadAccount) {
+		t.Fatalf("account_key returned %v, want ErrBadAccount", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer, err := rpctest.LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = rpc.NewClient("http://replay.invalid", replayer.Option())
+
+	// Node errors are recorded and replayed too.
+	if _, err = c.AccountKey(ctx, "nano_1111"); !errors.Is(err, rpc.ErrBadAccount) {
+		t.Errorf("replayed account_key returned %v, want ErrBadAccount", err)
+	}
+
+	if _, err = c.AccountKey(ctx, n.Genesis()); !errors.Is(err, rpctest.ErrUnmatchedInteraction) {
+		t.Errorf("unrecorded request returned %v, want ErrUnmatchedInteraction", err)
+	}
+
+	if err = replayer.Verify(); !errors.Is(err, rpctest.ErrUnusedInteractions) || !strings.Contains(err.Error(), "block_count") {
+		t.Errorf("Verify returned %v, want ErrUnusedInteractions for block_count", err)
+	}
+}
+
+func TestCassetteRedaction(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := rpctest.NewRecorder(path)
+	ctx := context.Background()
+
+	c := n.Client(recorder.Option())
+	wallet, err := c.CreateWallet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const key = "34F0A37AAD20F4A260F0A5B3CB3D7FB50673212263E58A380BC10474BB039CE4"
+	if _, err = c.WalletAdd(ctx, wallet, key, false); err != nil {
+		t.Fatal(err)
+	}
+	if err = recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), key) {
+		t.Error("cassette contains the private key")
+	}
+	if !strings.Contains(string(raw), "wallet_add") {
+		t.Error("cassette is missing wallet_add")
+	}
+}
@@ -0,0 +1,152 @@
+package rpctest
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+)
+
+// Alphabet of the base32 encoding used in account numbers.
+const accountAlphabet = "13456789abcdefghijkmnopqrstuwxyz"
+
+// Lowest account number, e.g. to start walking the ledger.
+const burnAccount = "xrb_1111111111111111111111111111111111111111111111111111hifc8npp"
+
+// Previous of open state blocks and link of change blocks.
+const zeroHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// Returns n random bytes, hex encoded in upper case.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return strings.ToUpper(hex.EncodeToString(b))
+}
+
+// Derives a stand-in public key from a private key.
+// Real nodes use ed25519 with blake2b, which the fake node does not
+// need: keys only have to be stable and unique.
+func publicKey(private string) string {
+	sum := sha256.Sum256([]byte(private))
+
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// Derives a stand-in hash of a state block from its fields.
+// Real nodes hash them with blake2b.
+func blockHash(account, previous, representative, balance, link string) string {
+	h := sha256.New()
+	for _, f := range []string{account, previous, representative, balance, link} {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+	}
+
+	return strings.ToUpper(hex.EncodeToString(h.Sum(nil)))
+}
+
+// Derives the private key at index of seed.
+func deterministicKey(seed string, index uint32) string {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, index)
+	sum := sha256.Sum256(append([]byte(seed), b...))
+
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// Encodes a hex public key as an account number, with the checksum
+// of real account numbers: the 5 byte blake2b digest of the key,
+// reversed.
+func accountFromKey(key string) (string, bool) {
+	pub, err := hex.DecodeString(key)
+	if err != nil || len(pub) != 32 {
+		return "", false
+	}
+
+	sum := blake2b(pub, 5)
+	checksum := make([]byte, 5)
+	for i := range checksum {
+		checksum[i] = sum[4-i]
+	}
+
+	// 4 zero bits of padding followed by the 256 bit key make 52 characters.
+	return "xrb_" + encode32(append([]byte{0}, pub...), 52) + encode32(checksum, 8), true
+}
+
+// Decodes an account number into its hex public key,
+// verifying the checksum.
+func keyFromAccount(account string) (string, bool) {
+	var rest string
+	switch {
+	case strings.HasPrefix(account, "xrb_"):
+		rest = account[4:]
+	case strings.HasPrefix(account, "nano_"):
+		rest = account[5:]
+	default:
+		return "", false
+	}
+
+	if len(rest) != 60 {
+		return "", false
+	}
+
+	pub, ok := decode32(rest[:52], 33)
+	if !ok || pub[0] != 0 {
+		return "", false
+	}
+
+	key := strings.ToUpper(hex.EncodeToString(pub[1:]))
+	if expected, _ := accountFromKey(key); expected[4:] != rest {
+		return "", false
+	}
+
+	return key, true
+}
+
+// Encodes the low 5*n bits of b into n characters.
+func encode32(b []byte, n int) string {
+	out := make([]byte, n)
+	bits := len(b) * 8
+	for i := n - 1; i >= 0; i-- {
+		var v byte
+		for j := 0; j < 5; j++ {
+			pos := bits - 1 - ((n-1-i)*5 + j)
+			if pos >= 0 && b[pos/8]&(0x80>>(pos%8)) != 0 {
+				v |= 1 << j
+			}
+		}
+		out[i] = accountAlphabet[v]
+	}
+
+	return string(out)
+}
+
+// Decodes s into size bytes, the inverse of encode32.
+func decode32(s string, size int) ([]byte, bool) {
+	b := make([]byte, size)
+	bits := size * 8
+	n := len(s)
+	for i := 0; i < n; i++ {
+		v := strings.IndexByte(accountAlphabet, s[i])
+		if v < 0 {
+			return nil, false
+		}
+
+		for j := 0; j < 5; j++ {
+			if v&(1<<j) == 0 {
+				continue
+			}
+
+			pos := bits - 1 - ((n-1-i)*5 + j)
+			if pos < 0 {
+				return nil, false
+			}
+			b[pos/8] |= 0x80 >> (pos % 8)
+		}
+	}
+
+	return b, true
+}
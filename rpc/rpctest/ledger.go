@@ -0,0 +1,490 @@
+package rpctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Balance of the genesis account, i.e. the whole supply.
+var genesisBalance, _ = new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+
+type block struct {
+	hash           string
+	typ            string
+	account        string
+	previous       string
+	representative string
+	source         string
+	destination    string
+	link           string
+	subtype        string
+	balance        *big.Int
+	amount         *big.Int
+	work           string
+	signature      string
+	height         int
+	timestamp      int64
+}
+
+// Returns the kind of the block, its subtype for state blocks.
+func (b *block) kind() string {
+	if b.typ == "state" {
+		return b.subtype
+	}
+
+	return b.typ
+}
+
+// Returns the stringified json representation of the block,
+// the way nodes return block contents unless json_block is set.
+func (b *block) contents() string {
+	raw, _ := json.MarshalIndent(b.fields(), "", "    ")
+
+	return string(raw) + "\n"
+}
+
+// Returns the fields of the json representation of the block.
+func (b *block) fields() map[string]string {
+	m := map[string]string{
+		"type":      b.typ,
+		"work":      b.work,
+		"signature": b.signature,
+	}
+
+	switch b.typ {
+	case "send":
+		m["previous"] = b.previous
+		m["destination"] = b.destination
+		m["balance"] = fmt.Sprintf("%032X", b.balance)
+	case "receive":
+		m["previous"] = b.previous
+		m["source"] = b.source
+	case "open":
+		m["source"] = b.source
+		m["representative"] = b.representative
+		m["account"] = b.account
+	case "change":
+		m["previous"] = b.previous
+		m["representative"] = b.representative
+	case "state":
+		m["account"] = b.account
+		m["previous"] = b.previous
+		m["representative"] = b.representative
+		m["balance"] = b.balance.String()
+		m["link"] = b.link
+		m["link_as_account"], _ = accountFromKey(b.link)
+	}
+
+	return m
+}
+
+type account struct {
+	id       string
+	key      string
+	blocks   []string
+	repBlock string
+	rep      string
+	balance  *big.Int
+	modified int64
+}
+
+func (a *account) frontier() string {
+	return a.blocks[len(a.blocks)-1]
+}
+
+// Follows a change of representative by b, if any.
+func (a *account) follow(b *block) {
+	switch {
+	case b.typ == "open" || b.typ == "change":
+	case b.typ == "state" && b.representative != a.rep:
+	default:
+		return
+	}
+
+	a.rep = b.representative
+	a.repBlock = b.hash
+}
+
+type receivable struct {
+	hash        string
+	source      string
+	destination string
+	amount      *big.Int
+}
+
+type wallet struct {
+	id       string
+	seed     string
+	index    uint32
+	accounts []string
+	keys     map[string]string
+	rep      string
+	password string
+	locked   bool
+	work     map[string]string
+}
+
+func (w *wallet) contains(account string) bool {
+	_, ok := w.keys[account]
+
+	return ok
+}
+
+// Adds the account of private key to the wallet.
+func (w *wallet) add(private string) string {
+	id, _ := accountFromKey(publicKey(private))
+	if !w.contains(id) {
+		w.accounts = append(w.accounts, id)
+	}
+	w.keys[id] = private
+
+	return id
+}
+
+func (w *wallet) remove(account string) {
+	delete(w.keys, account)
+	for i, id := range w.accounts {
+		if id == account {
+			w.accounts = append(w.accounts[:i], w.accounts[i+1:]...)
+			return
+		}
+	}
+}
+
+// In-memory ledger. All methods must be called with Node.mu held.
+type ledger struct {
+	major      int
+	minor      int
+	genesis    string
+	accounts   map[string]*account
+	order      []string
+	blocks     map[string]*block
+	receivable map[string]*receivable
+	// Processed blocks whose previous block is missing, by hash.
+	unchecked map[string]*block
+	sendIDs   map[string]string
+	wallets   map[string]*wallet
+	// Accounts handed out by payment_begin.
+	payments       map[string]bool
+	receiveMinimum *big.Int
+	workPeers      []string
+}
+
+func newLedger() *ledger {
+	l := &ledger{
+		major:      10,
+		accounts:   make(map[string]*account),
+		blocks:     make(map[string]*block),
+		receivable: make(map[string]*receivable),
+		unchecked:  make(map[string]*block),
+		sendIDs:    make(map[string]string),
+		wallets:    make(map[string]*wallet),
+		payments:   make(map[string]bool),
+
+		receiveMinimum: new(big.Int).Exp(big.NewInt(10), big.NewInt(24), nil),
+	}
+
+	key := publicKey(randomHex(32))
+	l.genesis, _ = accountFromKey(key)
+
+	hash := randomHex(32)
+	l.addBlock(&block{
+		hash:           hash,
+		typ:            "open",
+		account:        l.genesis,
+		representative: l.genesis,
+		source:         key,
+		balance:        new(big.Int).Set(genesisBalance),
+		amount:         new(big.Int).Set(genesisBalance),
+	})
+
+	return l
+}
+
+// Appends b to the chain of its account, opening it if needed.
+func (l *ledger) addBlock(b *block) {
+	if b.work == "" {
+		b.work = strings.ToLower(randomHex(8))
+	}
+	if b.signature == "" {
+		b.signature = randomHex(64)
+	}
+	b.timestamp = time.Now().Unix()
+
+	a, ok := l.accounts[b.account]
+	if !ok {
+		key, _ := keyFromAccount(b.account)
+		a = &account{id: b.account, key: key}
+		l.accounts[b.account] = a
+		l.order = append(l.order, b.account)
+	}
+
+	a.blocks = append(a.blocks, b.hash)
+	a.balance = b.balance
+	a.modified = b.timestamp
+	b.height = len(a.blocks)
+	a.follow(b)
+
+	l.blocks[b.hash] = b
+}
+
+// Sends amount from source to destination.
+func (l *ledger) send(source, destination string, amount *big.Int) (*block, error) {
+	a, ok := l.accounts[source]
+	if !ok {
+		return nil, errAccountNotFound
+	}
+
+	if a.balance.Cmp(amount) < 0 {
+		return nil, errInsufficientBalance
+	}
+
+	b := &block{
+		hash:        randomHex(32),
+		typ:         "send",
+		account:     source,
+		previous:    a.frontier(),
+		destination: destination,
+		balance:     new(big.Int).Sub(a.balance, amount),
+		amount:      new(big.Int).Set(amount),
+	}
+	l.addBlock(b)
+
+	l.receivable[b.hash] = &receivable{
+		hash:        b.hash,
+		source:      source,
+		destination: destination,
+		amount:      b.amount,
+	}
+
+	return b, nil
+}
+
+// Receives the receivable send hash into account, opening
+// the account with representative if it has no blocks yet.
+func (l *ledger) receive(account, hash, representative string) (*block, error) {
+	r, ok := l.receivable[hash]
+	if !ok || r.destination != account {
+		return nil, errUnreceivable
+	}
+	delete(l.receivable, hash)
+
+	b := &block{
+		hash:    randomHex(32),
+		account: account,
+		source:  hash,
+		amount:  r.amount,
+	}
+
+	if a, ok := l.accounts[account]; ok {
+		b.typ = "receive"
+		b.previous = a.frontier()
+		b.balance = new(big.Int).Add(a.balance, r.amount)
+	} else {
+		b.typ = "open"
+		b.representative = representative
+		b.balance = new(big.Int).Set(r.amount)
+	}
+	l.addBlock(b)
+
+	return b, nil
+}
+
+// Changes the representative of account.
+func (l *ledger) change(account, representative string) (*block, error) {
+	a, ok := l.accounts[account]
+	if !ok {
+		return nil, errAccountNotFound
+	}
+
+	b := &block{
+		hash:           randomHex(32),
+		typ:            "change",
+		account:        account,
+		previous:       a.frontier(),
+		representative: representative,
+		balance:        a.balance,
+		amount:         new(big.Int),
+	}
+	l.addBlock(b)
+
+	return b, nil
+}
+
+// Adds the state block b to the ledger, deriving its subtype from the
+// change in balance and checking it against subtype, if set. With force,
+// b replaces the blocks following its previous block.
+func (l *ledger) process(b *block, subtype string, force bool) error {
+	if _, ok := l.blocks[b.hash]; ok {
+		return errOldBlock
+	}
+
+	balance := new(big.Int)
+	a, opened := l.accounts[b.account]
+	fork := false
+	switch {
+	case !opened && b.previous == zeroHash:
+	case !opened:
+		return errGapPrevious
+	case b.previous == zeroHash:
+		return errFork
+	case b.previous != a.frontier():
+		prev, ok := l.blocks[b.previous]
+		if !ok || prev.account != b.account {
+			return errGapPrevious
+		}
+		if !force {
+			return errFork
+		}
+		fork = true
+		balance = prev.balance
+	default:
+		balance = a.balance
+	}
+
+	var r *receivable
+	switch b.balance.Cmp(balance) {
+	case -1:
+		b.subtype = "send"
+		b.amount = new(big.Int).Sub(balance, b.balance)
+		b.destination, _ = accountFromKey(b.link)
+	case 1:
+		b.subtype = "receive"
+		if !opened {
+			b.subtype = "open"
+		}
+		b.amount = new(big.Int).Sub(b.balance, balance)
+		b.source = b.link
+
+		var ok bool
+		r, ok = l.receivable[b.link]
+		if !ok || r.destination != b.account {
+			return errUnreceivable
+		}
+		if r.amount.Cmp(b.amount) != 0 {
+			return errBalanceMismatch
+		}
+	default:
+		b.subtype = "change"
+		if b.link != zeroHash {
+			b.subtype = "epoch"
+		}
+		b.amount = new(big.Int)
+	}
+
+	if subtype != "" && subtype != b.subtype {
+		return errInvalidSubtype
+	}
+
+	if fork {
+		l.rollback(a, b.previous)
+	}
+
+	if r != nil {
+		delete(l.receivable, r.hash)
+	}
+
+	l.addBlock(b)
+
+	if b.subtype == "send" {
+		l.receivable[b.hash] = &receivable{
+			hash:        b.hash,
+			source:      b.account,
+			destination: b.destination,
+			amount:      b.amount,
+		}
+	}
+
+	return nil
+}
+
+// Removes the blocks of a following previous, making the sends they
+// received receivable again and dropping the ones they made.
+func (l *ledger) rollback(a *account, previous string) {
+	for a.frontier() != previous {
+		b := l.blocks[a.frontier()]
+		switch b.kind() {
+		case "send":
+			delete(l.receivable, b.hash)
+		case "receive", "open":
+			if src, ok := l.blocks[b.source]; ok {
+				l.receivable[src.hash] = &receivable{
+					hash:        src.hash,
+					source:      src.account,
+					destination: a.id,
+					amount:      b.amount,
+				}
+			}
+		}
+
+		delete(l.blocks, b.hash)
+		a.blocks = a.blocks[:len(a.blocks)-1]
+	}
+
+	a.balance = l.blocks[previous].balance
+	a.rep, a.repBlock = "", ""
+	for _, hash := range a.blocks {
+		a.follow(l.blocks[hash])
+	}
+}
+
+// Returns the receivable blocks of account.
+func (l *ledger) receivableOf(account string) []*receivable {
+	var r []*receivable
+	for _, p := range l.receivable {
+		if p.destination == account {
+			r = append(r, p)
+		}
+	}
+
+	return r
+}
+
+// Returns the total receivable amount of account.
+func (l *ledger) receivableAmount(account string) *big.Int {
+	sum := new(big.Int)
+	for _, p := range l.receivableOf(account) {
+		sum.Add(sum, p.amount)
+	}
+
+	return sum
+}
+
+// Returns the voting weight delegated to representative.
+func (l *ledger) weight(representative string) *big.Int {
+	sum := new(big.Int)
+	for _, a := range l.accounts {
+		if a.rep == representative {
+			sum.Add(sum, a.balance)
+		}
+	}
+
+	return sum
+}
+
+// Returns the balance of account, zero if it is not opened.
+func (l *ledger) balance(account string) *big.Int {
+	if a, ok := l.accounts[account]; ok {
+		return a.balance
+	}
+
+	return new(big.Int)
+}
+
+// Returns the counterparty and amount of b for history entries.
+func (l *ledger) counterparty(b *block) (string, *big.Int) {
+	switch b.kind() {
+	case "send":
+		return b.destination, b.amount
+	case "receive", "open":
+		if src, ok := l.blocks[b.source]; ok {
+			return src.account, b.amount
+		}
+		return l.genesis, b.amount
+	}
+
+	return "", b.amount
+}
@@ -0,0 +1,354 @@
+// Package rpctest provides a fake Nano node for testing code built on the
+// rpc package. The node keeps its ledger, wallets and receivable blocks in
+// memory and answers with the response shapes and error envelopes of a
+// real node.
+//
+// Account numbers carry the checksum of real ones, so real accounts are
+// accepted. Public keys, block hashes, signatures and work are stand-ins
+// that are stable and unique but not valid on the network. Actions acting
+// on the network, like bootstrap or keepalive, succeed without effect.
+// Actions the node does not implement fail with "Unknown command".
+//
+// Blocks are processed right away, except ones whose previous block is
+// missing. Those are rejected with "Gap previous block" and kept among
+// the unchecked blocks, as real nodes do.
+package rpctest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/s1na/nano-go/rpc"
+)
+
+// Error messages of the real node.
+var (
+	errWalletNotFound      = nodeError("Wallet not found")
+	errBadWallet           = nodeError("Bad wallet number")
+	errWalletLocked        = nodeError("Wallet is locked")
+	errBadAccount          = nodeError("Bad account number")
+	errAccountNotFound     = nodeError("Account not found")
+	errNotInWallet         = nodeError("Account not found in wallet")
+	errBlockNotFound       = nodeError("Block not found")
+	errBadHash             = nodeError("Bad block hash number")
+	errInsufficientBalance = nodeError("Insufficient balance")
+	errUnreceivable        = nodeError("Unreceivable")
+	errBadAmount           = nodeError("Bad amount number")
+	errUnknownCommand      = nodeError("Unknown command")
+	errBadRequest          = nodeError("Unable to parse JSON")
+	errBadBlock            = nodeError("Block is invalid")
+	errBadLink             = nodeError("Bad link number")
+	errOldBlock            = nodeError("Old block")
+	errFork                = nodeError("Fork")
+	errGapPrevious         = nodeError("Gap previous block")
+	errBalanceMismatch     = nodeError("Balance mismatch")
+	errInvalidSubtype      = nodeError("Invalid block balance for given subtype")
+	errUncheckedNotFound   = nodeError("Unchecked block not found")
+)
+
+// Returned by handlers to produce an {"error": ...} envelope.
+type nodeError string
+
+func (e nodeError) Error() string {
+	return string(e)
+}
+
+// Hook is called by a Node with every request before the node handles
+// it, along with the action of the request. The body of r can be read
+// again. A hook that wrote a response itself returns true, and the node
+// leaves the request to it.
+type Hook func(w http.ResponseWriter, r *http.Request, action string) bool
+
+// Node is a fake Nano node served over HTTP.
+type Node struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	ledger   *ledger
+	hook     Hook
+	requests map[string]int
+}
+
+// Starts a fake node with a funded genesis account and no wallets.
+// Callers should Close it when done.
+func NewNode() *Node {
+	n := NewUnstartedNode()
+	n.Start()
+
+	return n
+}
+
+// Returns a fake node that is not listening yet,
+// e.g. to start it with TLS.
+func NewUnstartedNode() *Node {
+	n := &Node{ledger: newLedger(), requests: make(map[string]int)}
+	n.Server = httptest.NewUnstartedServer(n)
+
+	return n
+}
+
+// Returns a client talking to the node.
+func (n *Node) Client(opts ...rpc.Option) *rpc.Client {
+	return rpc.NewClient(n.URL, opts...)
+}
+
+// Returns the account holding the whole supply.
+func (n *Node) Genesis() string {
+	return n.ledger.genesis
+}
+
+// Sends amount raw from the genesis account to account, leaving a
+// receivable block for it. Returns the hash of the send block.
+func (n *Node) Fund(account, amount string) (string, error) {
+	v, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return "", errBadAmount
+	}
+
+	if _, ok = keyFromAccount(account); !ok {
+		return "", errBadAccount
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	b, err := n.ledger.send(n.ledger.genesis, account, v)
+	if err != nil {
+		return "", err
+	}
+
+	return b.hash, nil
+}
+
+// Locks wallet until the password is entered again.
+func (n *Node) Lock(wallet string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	w, ok := n.ledger.wallets[wallet]
+	if !ok {
+		return errWalletNotFound
+	}
+	w.locked = true
+
+	return nil
+}
+
+// Makes the node report version major.minor. From V23 on, the node
+// also answers to the receivable names of the pending actions. From
+// V24 on, accounts_frontiers reports accounts it has no frontier of
+// under errors.
+func (n *Node) SetVersion(major, minor int) {
+	n.mu.Lock()
+	n.ledger.major, n.ledger.minor = major, minor
+	n.mu.Unlock()
+}
+
+// Calls hook with every request received from now on, replacing the
+// previous hook. Hooks are called concurrently and may block, e.g. to
+// hold requests or to fail some of them.
+func (n *Node) OnRequest(hook Hook) {
+	n.mu.Lock()
+	n.hook = hook
+	n.mu.Unlock()
+}
+
+// Returns the number of requests of action the node received,
+// including the ones a hook answered.
+func (n *Node) Requests(action string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.requests[action]
+}
+
+func (n *Node) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return
+	}
+
+	var p params
+	decodeErr := json.Unmarshal(body, &p)
+	action, _ := p["action"].(string)
+
+	n.mu.Lock()
+	n.requests[action]++
+	hook := n.hook
+	n.mu.Unlock()
+
+	if hook != nil {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if hook(w, r, action) {
+			return
+		}
+	}
+
+	if decodeErr != nil {
+		writeJSON(w, map[string]string{"error": errBadRequest.Error()})
+		return
+	}
+
+	n.mu.Lock()
+	if alias, ok := receivableAliases[action]; ok && n.ledger.major >= 23 {
+		action = alias
+	}
+	n.mu.Unlock()
+
+	h, ok := handlers[action]
+	if !ok {
+		writeJSON(w, map[string]string{"error": errUnknownCommand.Error()})
+		return
+	}
+
+	n.mu.Lock()
+	res, err := h(n.ledger, p)
+	n.mu.Unlock()
+
+	if err != nil {
+		writeJSON(w, map[string]string{"error": err.Error()})
+		return
+	}
+
+	writeJSON(w, res)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// Decoded request body.
+type params map[string]interface{}
+
+func (p params) str(key string) string {
+	switch v := p[key].(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	return ""
+}
+
+func (p params) has(key string) bool {
+	_, ok := p[key]
+
+	return ok
+}
+
+// Accepts booleans as well as "true"/"false" and "1"/"0" strings.
+func (p params) flag(key string) bool {
+	switch v := p[key].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true" || v == "1"
+	}
+
+	return false
+}
+
+// Returns the integer at key, or def if it is missing.
+func (p params) int(key string, def int) (int, error) {
+	switch v := p[key].(type) {
+	case nil:
+		return def, nil
+	case float64:
+		return int(v), nil
+	case string:
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, nodeError(fmt.Sprintf("Invalid %s", key))
+		}
+		return i, nil
+	}
+
+	return 0, nodeError(fmt.Sprintf("Invalid %s", key))
+}
+
+func (p params) amount(key string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(p.str(key), 10)
+	if !ok || v.Sign() < 0 {
+		return nil, errBadAmount
+	}
+
+	return v, nil
+}
+
+func (p params) strs(key string) []string {
+	raw, _ := p[key].([]interface{})
+	r := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			r = append(r, s)
+		}
+	}
+
+	return r
+}
+
+// Returns the account at key, verifying it is a valid account number.
+func (p params) account(key string) (string, error) {
+	a := p.str(key)
+	if _, ok := keyFromAccount(a); !ok {
+		return "", errBadAccount
+	}
+
+	return a, nil
+}
+
+func (p params) hash(key string) (string, error) {
+	h := strings.ToUpper(p.str(key))
+	if len(h) != 64 {
+		return "", errBadHash
+	}
+
+	return h, nil
+}
+
+// Returns the wallet named by the wallet param.
+func (p params) wallet(l *ledger) (*wallet, error) {
+	id := strings.ToUpper(p.str("wallet"))
+	if len(id) != 64 {
+		return nil, errBadWallet
+	}
+
+	w, ok := l.wallets[id]
+	if !ok {
+		return nil, errWalletNotFound
+	}
+
+	return w, nil
+}
+
+// Like wallet, but fails if the wallet is locked.
+func (p params) unlockedWallet(l *ledger) (*wallet, error) {
+	w, err := p.wallet(l)
+	if err != nil {
+		return nil, err
+	}
+
+	if w.locked {
+		return nil, errWalletLocked
+	}
+
+	return w, nil
+}
+
+func boolString(b bool) string {
+	if b {
+		return "1"
+	}
+
+	return "0"
+}
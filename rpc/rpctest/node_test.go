@@ -0,0 +1,329 @@
+package rpctest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+func TestPaymentFlow(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	c := n.Client()
+	ctx := context.Background()
+
+	wallet, err := c.CreateWallet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := c.CreateAccount(ctx, wallet, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := n.Fund(account, "1000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := c.Pending(ctx, account, 10, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blocks, _ := pending.([]interface{}); len(blocks) != 1 || blocks[0] != hash {
+		t.Fatalf("pending = %v, want [%s]", pending, hash)
+	}
+
+	if _, err = c.AccountInfo(ctx, account, false, false, false, false); !errors.Is(err, rpc.ErrAccountNotFound) {
+		t.Errorf("info of an unopened account returned %v, want ErrAccountNotFound", err)
+	}
+
+	open, err := c.ReceiveBlock(ctx, wallet, account, hash, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent, err := c.Send(ctx, wallet, account, n.Genesis(), "", 400, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := c.AccountInfo(ctx, account, true, false, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Frontier != sent || info.OpenBlock != open {
+		t.Errorf("frontier %s and open block %s, want %s and %s", info.Frontier, info.OpenBlock, sent, open)
+	}
+	if info.Balance.String() != "600" || info.Pending.Sign() != 0 || info.BlockCount != 2 {
+		t.Errorf("balance %v, pending %v and block count %d, want 600, 0 and 2", info.Balance, info.Pending, info.BlockCount)
+	}
+
+	history, err := c.AccountHistory(ctx, account, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct{ typ, amount, hash string }{
+		{"send", "400", sent},
+		{"receive", "1000", open},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("history has %d entries, want %d", len(history), len(want))
+	}
+	for i, w := range want {
+		if h := history[i]; h["type"] != w.typ || h["amount"] != w.amount || h["hash"] != w.hash {
+			t.Errorf("history[%d] = %v, want a %s of %s in %s", i, h, w.typ, w.amount, w.hash)
+		}
+	}
+}
+
+func TestErrorEnvelopes(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	c := n.Client()
+	ctx := context.Background()
+
+	wallet, err := c.CreateWallet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := c.CreateAccount(ctx, wallet, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = n.Lock(wallet); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want error
+	}{
+		{"locked wallet", func() error {
+			_, err := c.Send(ctx, wallet, account, n.Genesis(), "", 1, "")
+			return err
+		}, rpc.ErrWalletLocked},
+		{"unknown wallet", func() error {
+			_, err := c.AccountList(ctx, "000000000000000000000000000000000000000000000000000000000000000A")
+			return err
+		}, rpc.ErrWalletNotFound},
+		{"bad account", func() error {
+			_, _, err := c.AccountBalance(ctx, "nano_1111")
+			return err
+		}, rpc.ErrBadAccount},
+		{"unknown block", func() error {
+			_, err := c.GetBlock(ctx, "0000000000000000000000000000000000000000000000000000000000000001")
+			return err
+		}, rpc.ErrBlockNotFound},
+		{"unknown action", func() error {
+			return c.Call(ctx, "no_such_action", nil, nil)
+		}, rpc.ErrUnknownAction},
+	}
+
+	for _, tt := range tests {
+		err := tt.call()
+
+		var nodeErr *rpc.NodeError
+		if !errors.As(err, &nodeErr) || !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want a *rpc.NodeError matching %v", tt.name, err, tt.want)
+		}
+	}
+
+	// Entering the password unlocks the wallet again.
+	if _, err = c.EnterWalletPassword(ctx, wallet, ""); err != nil {
+		t.Fatal(err)
+	}
+	if locked, err := c.IsWalletLocked(ctx, wallet); err != nil || locked {
+		t.Errorf("wallet locked = %v, %v after entering the password", locked, err)
+	}
+}
+
+func TestOnRequest(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	c := n.Client()
+	ctx := context.Background()
+
+	var bodies []string
+	n.OnRequest(func(w http.ResponseWriter, r *http.Request, action string) bool {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		if action != "version" {
+			return false
+		}
+
+		w.Write([]byte(`{"error": "Unknown command"}`))
+		return true
+	})
+
+	// Hooks answering a request keep the node from seeing it,
+	// others see the body the node handles afterwards.
+	if _, err := c.Version(ctx); !errors.Is(err, rpc.ErrUnknownAction) {
+		t.Errorf("version answered by the hook returned %v", err)
+	}
+	if _, err := c.BlockCount(ctx); err != nil {
+		t.Errorf("block_count passed on by the hook returned %v", err)
+	}
+	if len(bodies) != 2 || bodies[1] != `{"action":"block_count"}` {
+		t.Errorf("hook read bodies %q", bodies)
+	}
+
+	// Requests are counted whoever answers them.
+	n.OnRequest(nil)
+	if _, err := c.Version(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := n.Requests("version"); got != 2 {
+		t.Errorf("node counted %d version requests, want 2", got)
+	}
+	if got := n.Requests("block_count"); got != 1 {
+		t.Errorf("node counted %d block_count requests, want 1", got)
+	}
+}
+
+func TestRealAccounts(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	c := n.Client()
+	ctx := context.Background()
+
+	// Genesis of the live network, with both prefixes.
+	for _, account := range []string{
+		"nano_3t6k35gi95xu6tergt6p69ck76ogmitsa8mnijtpxm9fkcm736xtoncuohr3",
+		"xrb_3t6k35gi95xu6tergt6p69ck76ogmitsa8mnijtpxm9fkcm736xtoncuohr3",
+	} {
+		valid, err := c.ValidateAccountNumber(ctx, account)
+		if err != nil || !valid {
+			t.Errorf("%s: valid = %v, %v", account, valid, err)
+		}
+
+		key, err := c.AccountKey(ctx, account)
+		if err != nil || key != "E89208DD038FBB269987689621D52292AE9C35941A7484756ECCED92A65093BA" {
+			t.Errorf("%s: key = %s, %v", account, key, err)
+		}
+	}
+
+	// The last character is part of the checksum.
+	if valid, _ := c.ValidateAccountNumber(ctx, "nano_3t6k35gi95xu6tergt6p69ck76ogmitsa8mnijtpxm9fkcm736xtoncuohr1"); valid {
+		t.Error("account with a bad checksum is valid")
+	}
+}
+
+// Every action wrapped by package rpc has a handler, so calls
+// fail on their params instead of with "Unknown command".
+func TestSpecActions(t *testing.T) {
+	raw, err := os.ReadFile("../actions.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var spec []struct {
+		Action string `json:"action"`
+	}
+	if err = json.Unmarshal(raw, &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+
+	for _, entry := range spec {
+		err := c.Call(context.Background(), entry.Action, nil, nil)
+		if errors.Is(err, rpc.ErrUnknownAction) {
+			t.Errorf("%s is not implemented", entry.Action)
+		}
+	}
+}
+
+func TestPayments(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	c := n.Client()
+	ctx := context.Background()
+
+	wallet, err := c.CreateWallet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status, err := c.InitPayment(ctx, wallet); err != nil || status != "Ready" {
+		t.Fatalf("payment_init = %q, %v", status, err)
+	}
+
+	account, err := c.BeginPayment(ctx, wallet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if other, err := c.BeginPayment(ctx, wallet); err != nil || other == account {
+		t.Errorf("second payment got account %s, %v, want another one", other, err)
+	}
+
+	if status, err := c.WaitPayment(ctx, account, "100", 0); err != nil || status != "nothing" {
+		t.Errorf("payment_wait before paying = %q, %v", status, err)
+	}
+
+	hash, err := n.Fund(account, "100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.ReceiveBlock(ctx, wallet, account, hash, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if status, err := c.WaitPayment(ctx, account, "100", 0); err != nil || status != "success" {
+		t.Errorf("payment_wait after paying = %q, %v", status, err)
+	}
+
+	if err = c.EndPayment(ctx, wallet, account); err == nil {
+		t.Error("ended a payment of an account with a balance")
+	}
+}
+
+func TestWorkPeers(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+
+	c := n.Client()
+	ctx := context.Background()
+
+	if peers, err := c.GetWorkPeers(ctx); err != nil || len(peers) != 0 {
+		t.Fatalf("work peers = %v, %v, want none", peers, err)
+	}
+
+	if _, err := c.AddWorkPeer(ctx, "::ffff:127.0.0.1", "7000"); err != nil {
+		t.Fatal(err)
+	}
+
+	if peers, err := c.GetWorkPeers(ctx); err != nil || len(peers) != 1 || peers[0] != "::ffff:127.0.0.1:7000" {
+		t.Errorf("work peers = %v, %v", peers, err)
+	}
+
+	if _, err := c.ClearWorkPeers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if peers, err := c.GetWorkPeers(ctx); err != nil || len(peers) != 0 {
+		t.Errorf("work peers after clearing = %v, %v", peers, err)
+	}
+}
@@ -0,0 +1,105 @@
+package rpctest
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"time"
+
+	"github.com/s1na/nano-go/rpc"
+)
+
+// RecordedSpan is a span finished by a SpanRecorder.
+type RecordedSpan struct {
+	Name        string
+	SpanContext rpc.SpanContext
+	Parent      rpc.SpanContext
+	Attributes  map[string]string
+	Errors      []error
+	Start       time.Time
+	End         time.Time
+}
+
+// SpanRecorder is an in-memory rpc.Tracer keeping every finished span,
+// for asserting on the spans a client produces.
+type SpanRecorder struct {
+	mu    sync.Mutex
+	spans []RecordedSpan
+}
+
+func NewSpanRecorder() *SpanRecorder {
+	return &SpanRecorder{}
+}
+
+// Starts a span, continuing the trace of the span context in ctx if any.
+func (r *SpanRecorder) Start(ctx context.Context, name string) (context.Context, rpc.Span) {
+	s := &recordingSpan{
+		recorder: r,
+		span: RecordedSpan{
+			Name:       name,
+			Attributes: make(map[string]string),
+			Start:      time.Now(),
+		},
+	}
+
+	if parent, ok := rpc.SpanContextFromContext(ctx); ok {
+		s.span.Parent = parent
+		s.span.SpanContext.TraceID = parent.TraceID
+	} else {
+		rand.Read(s.span.SpanContext.TraceID[:])
+	}
+	rand.Read(s.span.SpanContext.SpanID[:])
+	s.span.SpanContext.Sampled = true
+
+	return rpc.ContextWithSpanContext(ctx, s.span.SpanContext), s
+}
+
+// Returns the spans finished so far.
+func (r *SpanRecorder) Spans() []RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]RecordedSpan(nil), r.spans...)
+}
+
+type recordingSpan struct {
+	recorder *SpanRecorder
+
+	mu   sync.Mutex
+	span RecordedSpan
+	done bool
+}
+
+func (s *recordingSpan) SetAttribute(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.span.Attributes[key] = value
+}
+
+func (s *recordingSpan) RecordError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.span.Errors = append(s.span.Errors, err)
+}
+
+func (s *recordingSpan) End() {
+	s.mu.Lock()
+	if s.done {
+		s.mu.Unlock()
+		return
+	}
+	s.done = true
+	s.span.End = time.Now()
+	span := s.span
+	s.mu.Unlock()
+
+	s.recorder.mu.Lock()
+	s.recorder.spans = append(s.recorder.spans, span)
+	s.recorder.mu.Unlock()
+}
+
+func (s *recordingSpan) SpanContext() rpc.SpanContext {
+	return s.span.SpanContext
+}
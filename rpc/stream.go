@@ -0,0 +1,261 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+)
+
+// UncheckedBlock is a block waiting for a dependency.
+type UncheckedBlock struct {
+	Hash     string
+	Contents Block
+}
+
+// Delegator is an account delegating its balance to a representative.
+type Delegator struct {
+	Account string
+	Balance string
+}
+
+// Like Ledger, but decodes the response as it arrives and yields
+// every account as soon as it is decoded, in the order of the
+// response. Iteration stops at the first error, which is yielded.
+// Requires enable_control.
+func (c *Client) StreamLedger(ctx context.Context, account string, count int, representative, weight, pending, sorting bool) iter.Seq2[LedgerEntry, error] {
+	payload := map[string]interface{}{
+		"account":        account,
+		"count":          count,
+		"representative": representative,
+		"weight":         weight,
+		"pending":        pending,
+		"sorting":        sorting,
+	}
+
+	return streamEntries(c, ctx, "ledger", payload, "accounts", func(account string, info *Account) LedgerEntry {
+		return LedgerEntry{Account: account, Info: info}
+	})
+}
+
+// Like UncheckedBlocks, but decodes the response as it arrives and
+// yields every block as soon as it is decoded. Iteration stops at
+// the first error, which is yielded.
+func (c *Client) StreamUncheckedBlocks(ctx context.Context, count int) iter.Seq2[UncheckedBlock, error] {
+	payload := map[string]interface{}{
+		"count": count,
+	}
+
+	return streamEntries(c, ctx, "unchecked", payload, "blocks", func(hash string, contents anyBlock) UncheckedBlock {
+		contents.setHash(strings.ToUpper(hash))
+		return UncheckedBlock{Hash: hash, Contents: contents.Block}
+	})
+}
+
+// Like Frontiers, but decodes the response as it arrives and
+// yields every frontier as soon as it is decoded. Iteration
+// stops at the first error, which is yielded.
+func (c *Client) StreamFrontiers(ctx context.Context, account string, count int) iter.Seq2[Frontier, error] {
+	payload := map[string]interface{}{
+		"account": account,
+		"count":   count,
+	}
+
+	return streamEntries(c, ctx, "frontiers", payload, "frontiers", func(account, hash string) Frontier {
+		return Frontier{Account: account, Hash: hash}
+	})
+}
+
+// Like Delegators, but decodes the response as it arrives and
+// yields every delegator as soon as it is decoded. Iteration
+// stops at the first error, which is yielded.
+func (c *Client) StreamDelegators(ctx context.Context, account string) iter.Seq2[Delegator, error] {
+	payload := map[string]interface{}{
+		"account": account,
+	}
+
+	return streamEntries(c, ctx, "delegators", payload, "delegators", func(account, balance string) Delegator {
+		return Delegator{Account: account, Balance: balance}
+	})
+}
+
+type streamKey struct{}
+
+// Decoder of a streamed call, carried by its context down to the
+// transport. Transports implementing StreamTransport feed it the
+// response body instead of returning the response.
+type stream struct {
+	decode func(io.Reader) error
+	// Whether entries were passed on, after which
+	// the call must not be retried.
+	started bool
+}
+
+func (s *stream) consume(r io.Reader) error {
+	return s.decode(r)
+}
+
+func streamFrom(ctx context.Context) *stream {
+	s, _ := ctx.Value(streamKey{}).(*stream)
+
+	return s
+}
+
+// Reports whether the call of ctx already passed entries on.
+func streamed(ctx context.Context) bool {
+	s := streamFrom(ctx)
+
+	return s != nil && s.started
+}
+
+// Returned by the walker when the consumer stopped iterating.
+var errStopStream = errors.New("rpc: stream stopped")
+
+// Calls action and yields the entries of the object at key of the
+// response, streaming the response if the transport supports it.
+// Interceptors see a nil response for streamed calls.
+func streamEntries[V, E any](c *Client, ctx context.Context, action string, payload map[string]interface{}, key string, entry func(string, V) E) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		var zero E
+
+		s := &stream{}
+		s.decode = func(r io.Reader) error {
+			err := walkObject(r, action, key, func(k string, v V) error {
+				s.started = true
+				if !yield(entry(k, v), nil) {
+					return errStopStream
+				}
+
+				return nil
+			})
+			if err == errStopStream {
+				return nil
+			}
+
+			return err
+		}
+
+		request := make(map[string]interface{}, len(payload))
+		for k, v := range payload {
+			request[k] = v
+		}
+
+		raw, err := c.call(context.WithValue(ctx, streamKey{}, s), action, request)
+
+		// Responses of transports without streaming, or of
+		// interceptors answering themselves, arrive buffered.
+		if err == nil && raw != nil {
+			err = s.consume(bytes.NewReader(raw))
+		}
+
+		if err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// Decodes the response in r entry by entry, passing every entry of
+// the object at key to fn. Nodes send an empty string instead of an
+// empty object. Error envelopes are returned as a *NodeError.
+func walkObject[V any](r io.Reader, action, key string, fn func(string, V) error) error {
+	d := json.NewDecoder(r)
+
+	if err := expectDelim(d, action, '{'); err != nil {
+		return err
+	}
+
+	found := false
+	for d.More() {
+		name, err := d.Token()
+		if err != nil {
+			return decodeError(action, err)
+		}
+
+		switch name {
+		case "error":
+			var msg string
+			if err = d.Decode(&msg); err != nil {
+				return decodeError(action, err)
+			}
+			return &NodeError{Action: action, Message: msg}
+		case key:
+			found = true
+			if err = walkEntries(d, action, fn); err != nil {
+				return err
+			}
+		default:
+			var skip json.RawMessage
+			if err = d.Decode(&skip); err != nil {
+				return decodeError(action, err)
+			}
+		}
+	}
+
+	if !found {
+		return errMissingKey(action, key)
+	}
+
+	return nil
+}
+
+func walkEntries[V any](d *json.Decoder, action string, fn func(string, V) error) error {
+	t, err := d.Token()
+	if err != nil {
+		return decodeError(action, err)
+	}
+
+	if _, ok := t.(string); ok {
+		return nil
+	}
+
+	if t != json.Delim('{') {
+		return fmt.Errorf("%w: response of %s contains %v instead of an object", ErrMalformedResponse, action, t)
+	}
+
+	for d.More() {
+		k, err := d.Token()
+		if err != nil {
+			return decodeError(action, err)
+		}
+
+		var v V
+		if err = d.Decode(&v); err != nil {
+			return decodeError(action, err)
+		}
+
+		if err = fn(k.(string), v); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(d, action, '}')
+}
+
+func expectDelim(d *json.Decoder, action string, delim json.Delim) error {
+	t, err := d.Token()
+	if err != nil {
+		return decodeError(action, err)
+	}
+
+	if t != delim {
+		return fmt.Errorf("%w: response of %s contains %v instead of %v", ErrMalformedResponse, action, t, delim)
+	}
+
+	return nil
+}
+
+// Marks JSON errors as malformed responses, leaving
+// errors of the connection as they are.
+func decodeError(action string, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+		return malformed(action, err)
+	}
+
+	return err
+}
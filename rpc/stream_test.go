@@ -0,0 +1,201 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+const firstAccount = "nano_1111111111111111111111111111111111111111111111111111hifc8npp"
+
+func TestStreamFrontiers(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+	ctx := context.Background()
+
+	openAccounts(t, n, c, 5)
+
+	want, err := c.Frontiers(ctx, firstAccount, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]string)
+	for f, err := range c.StreamFrontiers(ctx, firstAccount, 100) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[f.Account] = f.Hash
+	}
+
+	if len(got) != len(want) {
+		t.Errorf("streamed %d frontiers, want %d", len(got), len(want))
+	}
+	for account, hash := range want {
+		if got[account] != hash {
+			t.Errorf("streamed frontier of %s is %s, want %s", account, got[account], hash)
+		}
+	}
+
+	var ledger int
+	for e, err := range c.StreamLedger(ctx, firstAccount, 100, false, false, false, false) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Info == nil || e.Info.Frontier != want[e.Account] {
+			t.Errorf("streamed ledger entry %+v", e)
+		}
+		ledger++
+	}
+	if ledger != len(want) {
+		t.Errorf("streamed %d ledger entries, want %d", ledger, len(want))
+	}
+}
+
+// Entries are yielded before the rest of the response arrives.
+func TestStreamIncremental(t *testing.T) {
+	rest := make(chan struct{})
+
+	n := rpctest.NewNode()
+	defer n.Close()
+	n.OnRequest(func(w http.ResponseWriter, r *http.Request, action string) bool {
+		w.Write([]byte(`{"frontiers": {"nano_first": "1",`))
+		w.(http.Flusher).Flush()
+
+		select {
+		case <-rest:
+		case <-time.After(5 * time.Second):
+		}
+		w.Write([]byte(` "nano_second": "2"}}`))
+
+		return true
+	})
+
+	var got []string
+	for f, err := range n.Client().StreamFrontiers(context.Background(), firstAccount, 2) {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) == 0 {
+			close(rest)
+		}
+		got = append(got, f.Account)
+	}
+
+	if len(got) != 2 || got[0] != "nano_first" || got[1] != "nano_second" {
+		t.Errorf("streamed %v", got)
+	}
+}
+
+// Streams failing after yielding entries aren't retried.
+func TestStreamNoRetry(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	n.OnRequest(func(w http.ResponseWriter, r *http.Request, action string) bool {
+		w.Write([]byte(`{"frontiers": {"nano_first": "1",`))
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	})
+
+	var entries int
+	var errs []error
+	for _, err := range n.Client(testRetry).StreamFrontiers(context.Background(), firstAccount, 2) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		entries++
+	}
+
+	if entries != 1 || len(errs) != 1 {
+		t.Errorf("yielded %d entries and errors %v, want one of each", entries, errs)
+	}
+	if requests := n.Requests("frontiers"); requests != 1 {
+		t.Errorf("node got %d requests, want 1", requests)
+	}
+}
+
+func TestStreamResponses(t *testing.T) {
+	tests := []struct {
+		body    string
+		entries int
+		err     error
+	}{
+		{`{"frontiers": {"nano_a": "1", "nano_b": "2"}}`, 2, nil},
+		{`{"other": [1, {"x": 2}], "frontiers": {"nano_a": "1"}}`, 1, nil},
+		{`{"frontiers": ""}`, 0, nil},
+		{`{"error": "Bad account number"}`, 0, rpc.ErrBadAccount},
+		{`{"frontiers": []}`, 0, rpc.ErrMalformedResponse},
+		{`{"frontiers": {"nano_a": 1}}`, 0, rpc.ErrMalformedResponse},
+		{`{"frontiers": {"nano_a": "1"`, 1, rpc.ErrMalformedResponse},
+		{`{}`, 0, rpc.ErrMalformedResponse},
+		{`[]`, 0, rpc.ErrMalformedResponse},
+	}
+
+	n := rpctest.NewNode()
+	defer n.Close()
+	c := n.Client()
+
+	for _, tt := range tests {
+		n.OnRequest(reply(tt.body))
+
+		var entries int
+		var err error
+		for _, e := range c.StreamFrontiers(context.Background(), firstAccount, 10) {
+			if e != nil {
+				err = e
+				continue
+			}
+			entries++
+		}
+
+		if entries != tt.entries || !errors.Is(err, tt.err) {
+			t.Errorf("%s: yielded %d entries and %v, want %d and %v", tt.body, entries, err, tt.entries, tt.err)
+		}
+	}
+}
+
+func TestResponseSize(t *testing.T) {
+	const body = `{"frontiers": {"nano_a": "1", "nano_b": "2"}}`
+	n := rpctest.NewNode()
+	defer n.Close()
+	n.OnRequest(reply(body))
+	ctx := context.Background()
+
+	tests := []struct {
+		max     int64
+		tooLong bool
+	}{
+		{0, false},
+		{int64(len(body)), false},
+		{int64(len(body)) - 1, true},
+		{8, true},
+	}
+
+	for _, tt := range tests {
+		c := n.Client(rpc.WithMaxResponseSize(tt.max))
+
+		var out map[string]interface{}
+		err := c.Call(ctx, "frontiers", nil, &out)
+		if errors.Is(err, rpc.ErrResponseTooLarge) != tt.tooLong {
+			t.Errorf("max %d: call returned %v", tt.max, err)
+		}
+
+		var streamErr error
+		for _, err := range c.StreamFrontiers(ctx, firstAccount, 10) {
+			if err != nil {
+				streamErr = err
+			}
+		}
+		if errors.Is(streamErr, rpc.ErrResponseTooLarge) != tt.tooLong {
+			t.Errorf("max %d: stream returned %v", tt.max, streamErr)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package rpc
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Tracer starts a span per RPC call. It mirrors the part of OpenTelemetry's
+// trace.Tracer used by the client, so an OpenTelemetry tracer can be
+// plugged in through a small adapter without this package depending on it.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is the part of an OpenTelemetry span used by the client.
+type Span interface {
+	SetAttribute(key, value string)
+	// Records err and marks the span as failed.
+	RecordError(err error)
+	End()
+	SpanContext() SpanContext
+}
+
+// SpanContext identifies a span for W3C trace context propagation.
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Sampled bool
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// Returns the W3C traceparent header value of sc.
+func (sc SpanContext) TraceParent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+
+	return "00-" + hex.EncodeToString(sc.TraceID[:]) + "-" + hex.EncodeToString(sc.SpanID[:]) + "-" + flags
+}
+
+type spanContextKey struct{}
+
+// Returns a context carrying sc, which the HTTP transport propagates
+// to the node or proxy as a traceparent header.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// Returns the span context stored in ctx, if any.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	sc, ok := ctx.Value(spanContextKey{}).(SpanContext)
+
+	return sc, ok && sc.IsValid()
+}
+
+// Adds the traceparent header for the span context in ctx.
+func injectTraceContext(ctx context.Context, header http.Header) {
+	if sc, ok := SpanContextFromContext(ctx); ok {
+		header.Set("traceparent", sc.TraceParent())
+	}
+}
+
+// Payload fields copied onto spans. Keys, seeds and passwords never are.
+var spanFields = []string{"wallet", "account", "hash"}
+
+// Traces every call with t.
+func WithTracer(t Tracer) Option {
+	return WithInterceptors(TracingInterceptor(t))
+}
+
+// Returns an interceptor opening a span per attempt, named after the
+// action, with any wallet, account or hash of the payload as attributes.
+// The host and port of the node the request was sent to, which for a
+// pool is only known once sent, are recorded too. Failed calls, node
+// errors included, are recorded on the span.
+func TracingInterceptor(t Tracer) Interceptor {
+	return func(ctx context.Context, action string, payload map[string]interface{}, next Invoker) ([]byte, error) {
+		ctx, span := t.Start(ctx, "nano/"+action)
+		defer span.End()
+
+		span.SetAttribute("rpc.system", "nano")
+		span.SetAttribute("rpc.method", action)
+
+		for _, field := range spanFields {
+			if v, ok := payload[field].(string); ok && v != "" {
+				span.SetAttribute("nano."+field, v)
+			}
+		}
+
+		ctx = ContextWithSpanContext(ctx, span.SpanContext())
+
+		var endpoint string
+		ctx = context.WithValue(ctx, endpointKey{}, &endpoint)
+
+		raw, err := next(ctx, action, payload)
+
+		if host, port := serverAddress(endpoint); host != "" {
+			span.SetAttribute("server.address", host)
+			if port != "" {
+				span.SetAttribute("server.port", port)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+		}
+
+		return raw, err
+	}
+}
+
+type endpointKey struct{}
+
+// Notes rawURL as the node the request of ctx was sent to,
+// for the tracing interceptor of the call.
+func setEndpoint(ctx context.Context, rawURL string) {
+	if endpoint, ok := ctx.Value(endpointKey{}).(*string); ok {
+		*endpoint = rawURL
+	}
+}
+
+// Returns the host and port of a node URL, leaving out credentials,
+// paths and queries, which may hold API keys of a proxy. IPC URLs
+// return the socket path as host.
+func serverAddress(rawURL string) (string, string) {
+	if strings.HasPrefix(rawURL, "unix://") {
+		return strings.TrimPrefix(rawURL, "unix://"), ""
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", ""
+	}
+
+	return u.Hostname(), u.Port()
+}
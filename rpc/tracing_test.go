@@ -0,0 +1,137 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/rpctest"
+)
+
+// Returns a hook keeping the traceparent header of every
+// request, and a func returning the headers kept so far.
+func traceparentHook() (rpctest.Hook, func() []string) {
+	var mu sync.Mutex
+	var headers []string
+
+	hook := func(w http.ResponseWriter, r *http.Request, action string) bool {
+		mu.Lock()
+		headers = append(headers, r.Header.Get("traceparent"))
+		mu.Unlock()
+
+		return false
+	}
+
+	return hook, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), headers...)
+	}
+}
+
+func TestTracing(t *testing.T) {
+	n := rpctest.NewNode()
+	defer n.Close()
+	hook, headers := traceparentHook()
+	n.OnRequest(hook)
+	u, _ := url.Parse(n.URL)
+
+	spans := rpctest.NewSpanRecorder()
+	// A proxy URL holding credentials and an API key.
+	c := rpc.NewClient("http://user:secret@"+u.Host+"/v1/secret?key=secret", rpc.WithTracer(spans))
+	ctx := context.Background()
+
+	account := n.Genesis()
+	if _, _, err := c.AccountBalance(ctx, account); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetBlock(ctx, "0000000000000000000000000000000000000000000000000000000000000001"); !errors.Is(err, rpc.ErrBlockNotFound) {
+		t.Fatalf("block_info returned %v, want ErrBlockNotFound", err)
+	}
+
+	recorded := spans.Spans()
+	if len(recorded) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(recorded))
+	}
+
+	balance := recorded[0]
+	if balance.Name != "nano/account_balance" {
+		t.Errorf("span name %q", balance.Name)
+	}
+	for key, want := range map[string]string{
+		"rpc.system":     "nano",
+		"rpc.method":     "account_balance",
+		"server.address": u.Hostname(),
+		"server.port":    u.Port(),
+		"nano.account":   account,
+	} {
+		if got := balance.Attributes[key]; got != want {
+			t.Errorf("attribute %s = %q, want %q", key, got, want)
+		}
+	}
+	for key, v := range balance.Attributes {
+		if strings.Contains(v, "secret") {
+			t.Errorf("attribute %s = %q leaks the URL", key, v)
+		}
+	}
+	if len(balance.Errors) != 0 {
+		t.Errorf("successful call recorded errors %v", balance.Errors)
+	}
+
+	block := recorded[1]
+	if len(block.Errors) != 1 || !errors.Is(block.Errors[0], rpc.ErrBlockNotFound) {
+		t.Errorf("span of a node error recorded %v", block.Errors)
+	}
+
+	got := headers()
+	if len(got) != 2 {
+		t.Fatalf("node got %d requests, want 2", len(got))
+	}
+	for i, span := range recorded {
+		if want := span.SpanContext.TraceParent(); got[i] != want {
+			t.Errorf("request %d has traceparent %q, want %q", i, got[i], want)
+		}
+	}
+}
+
+// Spans of a pool name the node that served the request.
+func TestTracingPool(t *testing.T) {
+	var ports []string
+	var endpoints []string
+	for i := 0; i < 2; i++ {
+		n := rpctest.NewNode()
+		defer n.Close()
+		u, _ := url.Parse(n.URL)
+		ports = append(ports, u.Port())
+		endpoints = append(endpoints, n.URL)
+	}
+
+	spans := rpctest.NewSpanRecorder()
+	c := rpc.NewPoolClient(rpc.PoolConfig{
+		Endpoints: endpoints,
+		Balancing: rpc.RoundRobin,
+	}, rpc.WithTracer(spans))
+	defer c.Close()
+
+	for range endpoints {
+		if _, err := c.BlockCount(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, span := range spans.Spans() {
+		seen[span.Attributes["server.port"]] = true
+	}
+	for _, port := range ports {
+		if !seen[port] {
+			t.Errorf("no span for the node on port %s, got %v", port, seen)
+		}
+	}
+}
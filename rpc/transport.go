@@ -0,0 +1,168 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Transport carries an encoded request to the node and returns the raw
+// response. Implementations report node errors by returning the response
+// carrying them; the client turns error envelopes into errors.
+type Transport interface {
+	RoundTrip(ctx context.Context, action string, request []byte) ([]byte, error)
+}
+
+// StreamTransport is a Transport that can hand the response to a decoder
+// as it arrives instead of buffering it. Stream returns the error of fn,
+// or the error that kept the response from reaching fn.
+type StreamTransport interface {
+	Transport
+	Stream(ctx context.Context, action string, request []byte, fn func(io.Reader) error) error
+}
+
+// Returns the transport for url: IPC for unix:// URLs, HTTP otherwise.
+func (c *Client) newTransport(url string) Transport {
+	if strings.HasPrefix(url, "unix://") {
+		return c.wrap(&ipcTransport{
+			path:        strings.TrimPrefix(url, "unix://"),
+			maxResponse: c.maxResponse,
+		})
+	}
+
+	return c.wrap(&httpTransport{
+		url:         url,
+		client:      c.httpClient,
+		header:      c.header,
+		userAgent:   c.userAgent,
+		basicAuth:   c.basicAuth,
+		username:    c.username,
+		password:    c.password,
+		maxResponse: c.maxResponse,
+	})
+}
+
+// Applies the wrappers of the client to t.
+func (c *Client) wrap(t Transport) Transport {
+	for _, wrap := range c.wrappers {
+		t = wrap(t)
+	}
+
+	return t
+}
+
+// Posts requests to the node's HTTP RPC server.
+type httpTransport struct {
+	url       string
+	client    *http.Client
+	header    http.Header
+	userAgent string
+
+	basicAuth bool
+	username  string
+	password  string
+
+	maxResponse int64
+}
+
+func (t *httpTransport) RoundTrip(ctx context.Context, action string, body []byte) ([]byte, error) {
+	var raw []byte
+	err := t.Stream(ctx, action, body, func(r io.Reader) (err error) {
+		raw, err = io.ReadAll(r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return raw, nil
+}
+
+func (t *httpTransport) Stream(ctx context.Context, action string, body []byte, fn func(io.Reader) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, values := range t.header {
+		req.Header[key] = values
+	}
+
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+
+	if t.basicAuth {
+		req.SetBasicAuth(t.username, t.password)
+	}
+
+	injectTraceContext(ctx, req.Header)
+
+	res, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	r := limitResponse(res.Body, t.maxResponse)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		raw, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+
+		if err = checkEnvelope(action, raw); err != nil {
+			return err
+		}
+
+		return &StatusError{Action: action, StatusCode: res.StatusCode, Body: raw}
+	}
+
+	return fn(r)
+}
+
+// Returns a reader failing with ErrResponseTooLarge once more than
+// max bytes were read from r. A max of zero disables the check.
+func limitResponse(r io.Reader, max int64) io.Reader {
+	if max <= 0 {
+		return r
+	}
+
+	return &limitedReader{r: r, max: max, left: max}
+}
+
+type limitedReader struct {
+	r    io.Reader
+	max  int64
+	left int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.left <= 0 {
+		// Responses of exactly max bytes are fine.
+		var b [1]byte
+		if n, err := l.r.Read(b[:]); n == 0 {
+			return 0, err
+		}
+
+		return 0, tooLarge(l.max)
+	}
+
+	if int64(len(p)) > l.left {
+		p = p[:l.left]
+	}
+
+	n, err := l.r.Read(p)
+	l.left -= int64(n)
+
+	return n, err
+}
+
+func tooLarge(max int64) error {
+	return fmt.Errorf("rpc: %w: more than %d bytes", ErrResponseTooLarge, max)
+}
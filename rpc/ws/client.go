@@ -0,0 +1,457 @@
+// Package ws subscribes to the node's websocket server, delivering
+// confirmations, votes and other topics as typed events over channels.
+package ws
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrClosed            = errors.New("ws: client closed")
+	ErrAlreadySubscribed = errors.New("ws: already subscribed to topic")
+	// Returned for requests the node did not acknowledge
+	// before the connection dropped.
+	ErrDisconnected = errors.New("ws: connection lost")
+)
+
+// Option configures a Client created by Dial.
+type Option func(*Client)
+
+// Uses cfg for wss connections.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Client) {
+		c.tlsConfig = cfg
+	}
+}
+
+// Adds a header to the websocket handshake, e.g. an API key.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.header.Add(key, value)
+	}
+}
+
+// Bounds the delay between reconnection attempts. The delay starts
+// at min and doubles after every failed attempt up to max.
+func WithReconnectDelay(min, max time.Duration) Option {
+	return func(c *Client) {
+		c.minDelay = min
+		c.maxDelay = max
+	}
+}
+
+// Sets the capacity of event channels. Events arriving while their
+// channel is full are dropped and counted, see Client.Dropped, so a
+// slow consumer never holds up other topics or acknowledgements.
+func WithBufferSize(n int) Option {
+	return func(c *Client) {
+		c.bufferSize = n
+	}
+}
+
+// Client is a websocket connection to the node that transparently
+// reconnects and re-subscribes to every topic after a disconnect.
+type Client struct {
+	url        string
+	tlsConfig  *tls.Config
+	header     http.Header
+	minDelay   time.Duration
+	maxDelay   time.Duration
+	bufferSize int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu     sync.Mutex
+	conn   *conn
+	subs   map[string]*subscription
+	acks   map[string]chan error
+	nextID uint64
+}
+
+type subscription struct {
+	topic   string
+	options interface{}
+	deliver func(*envelope)
+	close   func()
+	dropped atomic.Uint64
+}
+
+// Connects to the node's websocket server at url,
+// e.g. ws://localhost:7078.
+func Dial(ctx context.Context, url string, opts ...Option) (*Client, error) {
+	c := &Client{
+		url:        url,
+		header:     make(http.Header),
+		minDelay:   100 * time.Millisecond,
+		maxDelay:   30 * time.Second,
+		bufferSize: 64,
+		done:       make(chan struct{}),
+		subs:       make(map[string]*subscription),
+		acks:       make(map[string]chan error),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	cn, err := dial(ctx, url, c.tlsConfig, c.header)
+	if err != nil {
+		return nil, err
+	}
+
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	c.conn = cn
+	go c.run(cn)
+
+	return c, nil
+}
+
+// Closes the connection and every event channel.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.ctx.Err() != nil {
+		c.mu.Unlock()
+		return nil
+	}
+
+	c.cancel()
+	cn := c.conn
+	c.mu.Unlock()
+
+	var err error
+	if cn != nil {
+		err = cn.close()
+	}
+
+	<-c.done
+
+	return err
+}
+
+// Subscribes to confirmed blocks matching opts.
+func (c *Client) SubscribeConfirmations(ctx context.Context, opts ConfirmationOptions) (<-chan Confirmation, error) {
+	return subscribe[Confirmation](ctx, c, TopicConfirmation, opts)
+}
+
+// Subscribes to votes matching opts.
+func (c *Client) SubscribeVotes(ctx context.Context, opts VoteOptions) (<-chan Vote, error) {
+	return subscribe[Vote](ctx, c, TopicVote, opts)
+}
+
+// Subscribes to elections stopped without confirmation.
+func (c *Client) SubscribeStoppedElections(ctx context.Context) (<-chan StoppedElection, error) {
+	return subscribe[StoppedElection](ctx, c, TopicStoppedElection, nil)
+}
+
+// Subscribes to changes of the active work difficulty.
+func (c *Client) SubscribeActiveDifficulty(ctx context.Context) (<-chan ActiveDifficulty, error) {
+	return subscribe[ActiveDifficulty](ctx, c, TopicActiveDifficulty, nil)
+}
+
+// Subscribes to telemetry received from peers.
+func (c *Client) SubscribeTelemetry(ctx context.Context) (<-chan Telemetry, error) {
+	return subscribe[Telemetry](ctx, c, TopicTelemetry, nil)
+}
+
+// Returns how many events of topic were dropped because
+// its channel was full, since subscribing to it.
+func (c *Client) Dropped(topic string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if sub, ok := c.subs[topic]; ok {
+		return sub.dropped.Load()
+	}
+
+	return 0
+}
+
+// Unsubscribes from topic and closes its event channel.
+func (c *Client) Unsubscribe(ctx context.Context, topic string) error {
+	c.mu.Lock()
+	sub, ok := c.subs[topic]
+	delete(c.subs, topic)
+	cn := c.conn
+	c.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	sub.close()
+
+	if cn == nil {
+		return nil
+	}
+
+	return c.request(ctx, cn, map[string]interface{}{
+		"action": "unsubscribe",
+		"topic":  topic,
+	})
+}
+
+func subscribe[T any, PT interface {
+	*T
+	event
+}](ctx context.Context, c *Client, topic string, options interface{}) (<-chan T, error) {
+	ch := make(chan T, c.bufferSize)
+
+	var (
+		mu     sync.Mutex
+		once   sync.Once
+		closed bool
+	)
+
+	sub := &subscription{
+		topic:   topic,
+		options: options,
+	}
+	sub.deliver = func(env *envelope) {
+		var v T
+		if err := json.Unmarshal(env.Message, &v); err != nil {
+			return
+		}
+		PT(&v).setTime(env.time())
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if closed {
+			return
+		}
+
+		// Never wait for the consumer, the read loop
+		// also delivers acks and the other topics.
+		select {
+		case ch <- v:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+	sub.close = func() {
+		once.Do(func() {
+			mu.Lock()
+			defer mu.Unlock()
+
+			closed = true
+			close(ch)
+		})
+	}
+
+	c.mu.Lock()
+	if c.ctx.Err() != nil {
+		c.mu.Unlock()
+		return nil, ErrClosed
+	}
+
+	if _, ok := c.subs[topic]; ok {
+		c.mu.Unlock()
+		return nil, ErrAlreadySubscribed
+	}
+
+	c.subs[topic] = sub
+	cn := c.conn
+	c.mu.Unlock()
+
+	// While reconnecting the subscription is sent once the
+	// connection is back.
+	if cn == nil {
+		return ch, nil
+	}
+
+	if err := c.request(ctx, cn, sub.message()); err != nil {
+		c.mu.Lock()
+		delete(c.subs, topic)
+		c.mu.Unlock()
+		sub.close()
+
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// Returns the subscribe message for s.
+func (s *subscription) message() map[string]interface{} {
+	m := map[string]interface{}{
+		"action": "subscribe",
+		"topic":  s.topic,
+	}
+
+	if s.options != nil {
+		m["options"] = s.options
+	}
+
+	return m
+}
+
+// Sends message and waits for the node to acknowledge it.
+func (c *Client) request(ctx context.Context, cn *conn, message map[string]interface{}) error {
+	c.mu.Lock()
+	c.nextID++
+	id := strconv.FormatUint(c.nextID, 10)
+	ack := make(chan error, 1)
+	c.acks[id] = ack
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.acks, id)
+		c.mu.Unlock()
+	}()
+
+	message["ack"] = true
+	message["id"] = id
+
+	raw, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	if err = cn.writeText(raw); err != nil {
+		return err
+	}
+
+	select {
+	case err = <-ack:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.ctx.Done():
+		return ErrClosed
+	}
+}
+
+// Reads from the connection until the client is closed,
+// reconnecting whenever the connection drops.
+func (c *Client) run(cn *conn) {
+	defer close(c.done)
+	defer c.closeSubscriptions()
+
+	for cn != nil {
+		c.read(cn)
+		cn.nc.Close()
+
+		// Requests still waiting won't be acknowledged on
+		// this connection, and reconnect doesn't ask for acks.
+		c.mu.Lock()
+		c.conn = nil
+		for id, ack := range c.acks {
+			ack <- ErrDisconnected
+			delete(c.acks, id)
+		}
+		c.mu.Unlock()
+
+		cn = c.reconnect()
+	}
+}
+
+func (c *Client) read(cn *conn) {
+	for {
+		raw, err := cn.readMessage()
+		if err != nil {
+			return
+		}
+
+		var env envelope
+		if err = json.Unmarshal(raw, &env); err != nil {
+			continue
+		}
+
+		c.mu.Lock()
+		if env.Ack != "" {
+			if ack, ok := c.acks[env.ID]; ok {
+				ack <- nil
+				delete(c.acks, env.ID)
+			}
+			c.mu.Unlock()
+			continue
+		}
+
+		sub := c.subs[env.Topic]
+		c.mu.Unlock()
+
+		if sub != nil {
+			sub.deliver(&env)
+		}
+	}
+}
+
+// Dials again with exponential backoff and re-subscribes to every
+// topic. Returns nil once the client is closed.
+func (c *Client) reconnect() *conn {
+	delay := c.minDelay
+	for {
+		t := time.NewTimer(delay)
+		select {
+		case <-c.ctx.Done():
+			t.Stop()
+			return nil
+		case <-t.C:
+		}
+
+		delay *= 2
+		if delay > c.maxDelay {
+			delay = c.maxDelay
+		}
+
+		cn, err := dial(c.ctx, c.url, c.tlsConfig, c.header)
+		if err != nil {
+			continue
+		}
+
+		c.mu.Lock()
+		if c.ctx.Err() != nil {
+			c.mu.Unlock()
+			cn.close()
+			return nil
+		}
+
+		var messages [][]byte
+		for _, sub := range c.subs {
+			raw, err := json.Marshal(sub.message())
+			if err == nil {
+				messages = append(messages, raw)
+			}
+		}
+		c.conn = cn
+		c.mu.Unlock()
+
+		for _, raw := range messages {
+			if err = cn.writeText(raw); err != nil {
+				break
+			}
+		}
+
+		if err != nil {
+			cn.nc.Close()
+			c.mu.Lock()
+			c.conn = nil
+			c.mu.Unlock()
+			continue
+		}
+
+		return cn
+	}
+}
+
+func (c *Client) closeSubscriptions() {
+	c.mu.Lock()
+	subs := c.subs
+	c.subs = make(map[string]*subscription)
+	c.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.close()
+	}
+}
@@ -0,0 +1,263 @@
+package ws_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/s1na/nano-go/rpc"
+	"github.com/s1na/nano-go/rpc/ws"
+	"github.com/s1na/nano-go/rpc/ws/wstest"
+)
+
+const testAccount = "nano_1111111111111111111111111111111111111111111111111111hifc8npp"
+
+func dial(t *testing.T, s *wstest.Server) *ws.Client {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c, err := ws.Dial(ctx, s.URL, ws.WithReconnectDelay(time.Millisecond, 10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	return c
+}
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	return ctx
+}
+
+// Receives the next event of ch, failing the test on timeout.
+func next[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			t.Fatal("channel closed")
+		}
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+
+	panic("unreachable")
+}
+
+func TestSubscribe(t *testing.T) {
+	s := wstest.NewServer()
+	defer s.Close()
+
+	c := dial(t, s)
+	ctx := testContext(t)
+
+	confirmations, err := c.SubscribeConfirmations(ctx, ws.ConfirmationOptions{Accounts: []string{testAccount}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subs := s.Subscriptions(ws.TopicConfirmation)
+	if len(subs) != 1 {
+		t.Fatalf("server has %d confirmation subscriptions, want 1", len(subs))
+	}
+
+	var options ws.ConfirmationOptions
+	if err = json.Unmarshal(subs[0], &options); err != nil {
+		t.Fatal(err)
+	}
+	if len(options.Accounts) != 1 || options.Accounts[0] != testAccount {
+		t.Errorf("subscribed with accounts %v, want [%s]", options.Accounts, testAccount)
+	}
+
+	if _, err = c.SubscribeConfirmations(ctx, ws.ConfirmationOptions{}); !errors.Is(err, ws.ErrAlreadySubscribed) {
+		t.Errorf("second subscription returned %v, want ErrAlreadySubscribed", err)
+	}
+
+	if _, err = s.Publish(ws.TopicConfirmation, map[string]interface{}{
+		"account": testAccount,
+		"amount":  "1000",
+		"hash":    "A170D51B94E00371ACE76E35AC81DC9405D5D04D4CEBC399AEACE07AE05DD293",
+		"block": map[string]string{
+			"type":           "state",
+			"account":        testAccount,
+			"previous":       "0000000000000000000000000000000000000000000000000000000000000000",
+			"representative": testAccount,
+			"balance":        "1000",
+			"link":           "0000000000000000000000000000000000000000000000000000000000000000",
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := next(t, confirmations)
+	if got.Account != testAccount || got.Amount != "1000" {
+		t.Errorf("got confirmation %+v", got)
+	}
+	if got.Time.IsZero() {
+		t.Error("confirmation has no time")
+	}
+	if b, ok := got.Block.(*rpc.StateBlock); !ok || b.Account != testAccount {
+		t.Errorf("got block %#v, want state block of %s", got.Block, testAccount)
+	}
+}
+
+func TestFullChannel(t *testing.T) {
+	s := wstest.NewServer()
+	defer s.Close()
+
+	ctx := testContext(t)
+	c, err := ws.Dial(ctx, s.URL, ws.WithBufferSize(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	difficulties, err := c.SubscribeActiveDifficulty(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err = s.Publish(ws.TopicActiveDifficulty, map[string]string{"multiplier": strconv.Itoa(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The ack is read after the events, with the channel full.
+	if _, err = c.SubscribeVotes(ctx, ws.VoteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Unsubscribe(ctx, ws.TopicVote); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := next(t, difficulties); got.Multiplier != "0" {
+		t.Errorf("got multiplier %s, want 0", got.Multiplier)
+	}
+	if n := c.Dropped(ws.TopicActiveDifficulty); n != 2 {
+		t.Errorf("dropped %d events, want 2", n)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	s := wstest.NewServer()
+	defer s.Close()
+
+	c := dial(t, s)
+	ctx := testContext(t)
+
+	votes, err := c.SubscribeVotes(ctx, ws.VoteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = c.Unsubscribe(ctx, ws.TopicVote); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := <-votes; ok {
+		t.Error("channel of unsubscribed topic is still open")
+	}
+
+	if subs := s.Subscriptions(ws.TopicVote); len(subs) != 0 {
+		t.Errorf("server still has %d vote subscriptions", len(subs))
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	s := wstest.NewServer()
+	defer s.Close()
+
+	c := dial(t, s)
+	ctx := testContext(t)
+
+	difficulty, err := c.SubscribeActiveDifficulty(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Disconnect()
+
+	// The client reconnects and subscribes again on its own.
+	if err = s.WaitSubscribed(ctx, ws.TopicActiveDifficulty); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = s.Publish(ws.TopicActiveDifficulty, map[string]string{"multiplier": "1.5"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := next(t, difficulty); got.Multiplier != "1.5" {
+		t.Errorf("got multiplier %q, want 1.5", got.Multiplier)
+	}
+}
+
+func TestSubscribeDisconnected(t *testing.T) {
+	s := wstest.NewServer()
+	defer s.Close()
+
+	c := dial(t, s)
+	ctx := testContext(t)
+
+	// Without an ack, only the dropped connection ends the wait.
+	s.SetAck(false)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := c.SubscribeTelemetry(context.Background())
+		errs <- err
+	}()
+
+	if err := s.WaitSubscribed(ctx, ws.TopicTelemetry); err != nil {
+		t.Fatal(err)
+	}
+	s.Disconnect()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, ws.ErrDisconnected) {
+			t.Errorf("subscribe returned %v, want ErrDisconnected", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscribe still waiting after the connection dropped")
+	}
+}
+
+func TestClose(t *testing.T) {
+	s := wstest.NewServer()
+	defer s.Close()
+
+	c := dial(t, s)
+	ctx := testContext(t)
+
+	stopped, err := c.SubscribeStoppedElections(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := <-stopped; ok {
+		t.Error("event channel is still open after Close")
+	}
+
+	if _, err = c.SubscribeVotes(ctx, ws.VoteOptions{}); !errors.Is(err, ws.ErrClosed) {
+		t.Errorf("subscribe after Close returned %v, want ErrClosed", err)
+	}
+
+	if err = c.Close(); err != nil {
+		t.Errorf("second Close returned %v", err)
+	}
+}
@@ -0,0 +1,292 @@
+package ws
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Frame opcodes defined by RFC 6455.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// Largest message accepted from the node.
+const maxMessageSize = 16 << 20
+
+var errMessageTooLarge = errors.New("ws: message too large")
+
+// A minimal RFC 6455 client connection, enough to talk to the node.
+type conn struct {
+	nc net.Conn
+	br *bufio.Reader
+
+	wmu sync.Mutex
+}
+
+// Opens a websocket connection to u, which may use
+// the ws, wss, http or https scheme.
+func dial(ctx context.Context, u string, tlsConfig *tls.Config, header http.Header) (*conn, error) {
+	target, err := url.Parse(u)
+	if err != nil {
+		return nil, err
+	}
+
+	secure := false
+	switch target.Scheme {
+	case "ws", "http":
+	case "wss", "https":
+		secure = true
+	default:
+		return nil, fmt.Errorf("ws: unsupported scheme %q", target.Scheme)
+	}
+
+	host := target.Host
+	if target.Port() == "" {
+		if secure {
+			host = net.JoinHostPort(target.Hostname(), "443")
+		} else {
+			host = net.JoinHostPort(target.Hostname(), "80")
+		}
+	}
+
+	var d net.Dialer
+	nc, err := d.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+
+	if secure {
+		cfg := tlsConfig.Clone()
+		if cfg == nil {
+			cfg = &tls.Config{}
+		}
+		if cfg.ServerName == "" {
+			cfg.ServerName = target.Hostname()
+		}
+
+		tc := tls.Client(nc, cfg)
+		if err = tc.HandshakeContext(ctx); err != nil {
+			nc.Close()
+			return nil, err
+		}
+		nc = tc
+	}
+
+	c := &conn{nc: nc, br: bufio.NewReader(nc)}
+	if err = c.handshake(ctx, target, header); err != nil {
+		nc.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Performs the HTTP upgrade.
+func (c *conn) handshake(ctx context.Context, target *url.URL, header http.Header) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		c.nc.SetDeadline(deadline)
+		defer c.nc.SetDeadline(time.Time{})
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	req := &http.Request{
+		Method:     http.MethodGet,
+		URL:        &url.URL{Path: target.Path, RawQuery: target.RawQuery},
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Host:       target.Host,
+	}
+	if req.URL.Path == "" {
+		req.URL.Path = "/"
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+
+	if err := req.Write(c.nc); err != nil {
+		return err
+	}
+
+	res, err := http.ReadResponse(c.br, req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		return fmt.Errorf("ws: handshake failed with status %s", res.Status)
+	}
+
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	if res.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(sum[:]) {
+		return errors.New("ws: handshake returned an invalid accept key")
+	}
+
+	return nil
+}
+
+// Writes a single masked frame.
+func (c *conn) writeFrame(opcode byte, payload []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	header := make([]byte, 0, 14)
+	header = append(header, 0x80|opcode)
+
+	n := len(payload)
+	switch {
+	case n < 126:
+		header = append(header, 0x80|byte(n))
+	case n <= 0xffff:
+		header = append(header, 0x80|126)
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header = append(header, 0x80|127)
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	var mask [4]byte
+	if _, err := rand.Read(mask[:]); err != nil {
+		return err
+	}
+	header = append(header, mask[:]...)
+
+	masked := make([]byte, n)
+	for i := range payload {
+		masked[i] = payload[i] ^ mask[i%4]
+	}
+
+	if _, err := c.nc.Write(append(header, masked...)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Sends a text message.
+func (c *conn) writeText(payload []byte) error {
+	return c.writeFrame(opText, payload)
+}
+
+// Reads the next complete data message, answering pings
+// on the way. Returns io.EOF once the peer closes the connection.
+func (c *conn) readMessage() ([]byte, error) {
+	var message []byte
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case opPing:
+			if err = c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			c.writeFrame(opClose, nil)
+			return nil, io.EOF
+		}
+
+		message = append(message, payload...)
+		if len(message) > maxMessageSize {
+			return nil, errMessageTooLarge
+		}
+
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+func (c *conn) readFrame() (bool, byte, []byte, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.br, head[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin := head[0]&0x80 != 0
+	opcode := head[0] & 0x0f
+	masked := head[1]&0x80 != 0
+
+	n := uint64(head[1] & 0x7f)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+
+	if n > maxMessageSize {
+		return false, 0, nil, errMessageTooLarge
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+
+	return fin, opcode, payload, nil
+}
+
+func (c *conn) close() error {
+	c.writeFrame(opClose, nil)
+
+	return c.nc.Close()
+}
@@ -0,0 +1,172 @@
+package ws
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/s1na/nano-go/rpc"
+)
+
+// Topics the node publishes over its websocket server.
+const (
+	TopicConfirmation     = "confirmation"
+	TopicVote             = "vote"
+	TopicStoppedElection  = "stopped_election"
+	TopicActiveDifficulty = "active_difficulty"
+	TopicTelemetry        = "telemetry"
+)
+
+// Envelope of every message published by the node.
+type envelope struct {
+	Topic   string          `json:"topic"`
+	Time    string          `json:"time"`
+	Ack     string          `json:"ack"`
+	ID      string          `json:"id"`
+	Message json.RawMessage `json:"message"`
+}
+
+// Returns the time the node published the message at.
+func (e *envelope) time() time.Time {
+	ms, err := strconv.ParseInt(e.Time, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(ms)
+}
+
+// Filters confirmations by account. With no options set,
+// the node publishes every confirmed block.
+type ConfirmationOptions struct {
+	// Only publish confirmations involving these accounts.
+	Accounts []string `json:"accounts,omitempty"`
+	// Also publish confirmations involving accounts in the node's wallets.
+	AllLocalAccounts bool `json:"all_local_accounts,omitempty"`
+	// One of "all" (the default), "active", "active_quorum",
+	// "active_confirmation_height" or "inactive".
+	ConfirmationType string `json:"confirmation_type,omitempty"`
+	// Include election info such as duration and tally.
+	IncludeElectionInfo bool `json:"include_election_info,omitempty"`
+	// Set to false to leave out the block contents.
+	IncludeBlock *bool `json:"include_block,omitempty"`
+}
+
+// Confirmation of a block by the network.
+type Confirmation struct {
+	Time             time.Time `json:"-"`
+	Account          string    `json:"account"`
+	Amount           string    `json:"amount"`
+	Hash             string    `json:"hash"`
+	ConfirmationType string    `json:"confirmation_type"`
+	// Contents of the block, nil if IncludeBlock was false. Its hash
+	// is Hash, the Hash method of the block returns "".
+	Block        rpc.Block     `json:"-"`
+	ElectionInfo *ElectionInfo `json:"election_info"`
+}
+
+func (e *Confirmation) UnmarshalJSON(data []byte) error {
+	type plain Confirmation
+	r := struct {
+		*plain
+		Block json.RawMessage `json:"block"`
+	}{plain: (*plain)(e)}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+
+	e.Block = nil
+	if len(r.Block) == 0 || bytes.Equal(r.Block, []byte("null")) {
+		return nil
+	}
+
+	var err error
+	e.Block, err = rpc.DecodeBlock(r.Block)
+
+	return err
+}
+
+type ElectionInfo struct {
+	Duration        string `json:"duration"`
+	Time            string `json:"time"`
+	Tally           string `json:"tally"`
+	BlockCount      string `json:"block_count"`
+	VoterCount      string `json:"voter_count"`
+	RequestCount    string `json:"request_count"`
+	FinalTally      string `json:"final"`
+	ConfirmedBlocks string `json:"confirmed_blocks"`
+}
+
+// Filters votes by representative.
+type VoteOptions struct {
+	Representatives []string `json:"representatives,omitempty"`
+	// Also publish replay and indeterminate votes.
+	IncludeReplays       bool `json:"include_replays,omitempty"`
+	IncludeIndeterminate bool `json:"include_indeterminate,omitempty"`
+}
+
+// Vote observed by the node.
+type Vote struct {
+	Time      time.Time `json:"-"`
+	Account   string    `json:"account"`
+	Signature string    `json:"signature"`
+	Sequence  string    `json:"sequence"`
+	Timestamp string    `json:"timestamp"`
+	Duration  string    `json:"duration"`
+	Blocks    []string  `json:"blocks"`
+	Type      string    `json:"type"`
+}
+
+// Election stopped without confirming the block.
+type StoppedElection struct {
+	Time time.Time `json:"-"`
+	Hash string    `json:"hash"`
+}
+
+// Change of the network's active work difficulty.
+type ActiveDifficulty struct {
+	Time                  time.Time `json:"-"`
+	NetworkMinimum        string    `json:"network_minimum"`
+	NetworkCurrent        string    `json:"network_current"`
+	NetworkReceiveMinimum string    `json:"network_receive_minimum"`
+	NetworkReceiveCurrent string    `json:"network_receive_current"`
+	Multiplier            string    `json:"multiplier"`
+}
+
+// Telemetry received from a peer.
+type Telemetry struct {
+	Time              time.Time `json:"-"`
+	BlockCount        string    `json:"block_count"`
+	CementedCount     string    `json:"cemented_count"`
+	UncheckedCount    string    `json:"unchecked_count"`
+	AccountCount      string    `json:"account_count"`
+	BandwidthCap      string    `json:"bandwidth_cap"`
+	PeerCount         string    `json:"peer_count"`
+	ProtocolVersion   string    `json:"protocol_version"`
+	Uptime            string    `json:"uptime"`
+	GenesisBlock      string    `json:"genesis_block"`
+	MajorVersion      string    `json:"major_version"`
+	MinorVersion      string    `json:"minor_version"`
+	PatchVersion      string    `json:"patch_version"`
+	PreReleaseVersion string    `json:"pre_release_version"`
+	Maker             string    `json:"maker"`
+	Timestamp         string    `json:"timestamp"`
+	ActiveDifficulty  string    `json:"active_difficulty"`
+	NodeID            string    `json:"node_id"`
+	Signature         string    `json:"signature"`
+	Address           string    `json:"address"`
+	Port              string    `json:"port"`
+}
+
+// Implemented by every event type so the read loop can stamp
+// the publication time on it.
+type event interface {
+	setTime(time.Time)
+}
+
+func (e *Confirmation) setTime(t time.Time)     { e.Time = t }
+func (e *Vote) setTime(t time.Time)             { e.Time = t }
+func (e *StoppedElection) setTime(t time.Time)  { e.Time = t }
+func (e *ActiveDifficulty) setTime(t time.Time) { e.Time = t }
+func (e *Telemetry) setTime(t time.Time)        { e.Time = t }
@@ -0,0 +1,332 @@
+// Package wstest provides a stand-in for the node's websocket server,
+// for testing code built on package ws without a node. The server keeps
+// track of the topics every connection subscribed to and publishes
+// messages to them in the envelope of the real node.
+package wstest
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// Frame opcodes defined by RFC 6455.
+const (
+	opText  = 0x1
+	opClose = 0x8
+	opPing  = 0x9
+	opPong  = 0xa
+)
+
+// Server is a fake websocket server of a node. Point ws.Dial at its URL.
+type Server struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	conns map[*conn]map[string]json.RawMessage
+	noAck bool
+	// Closed and replaced whenever a subscription changes.
+	changed chan struct{}
+}
+
+// A server side connection.
+type conn struct {
+	nc  net.Conn
+	br  *bufio.Reader
+	wmu sync.Mutex
+}
+
+// Starts a server. Callers should Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		conns:   make(map[*conn]map[string]json.RawMessage),
+		changed: make(chan struct{}),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+
+	return s
+}
+
+// Closes every connection and stops the server.
+func (s *Server) Close() {
+	s.Disconnect()
+	s.Server.Close()
+}
+
+// Stops acknowledging subscribe and unsubscribe requests if
+// enabled is false, like a node that hangs or is overloaded.
+func (s *Server) SetAck(enabled bool) {
+	s.mu.Lock()
+	s.noAck = !enabled
+	s.mu.Unlock()
+}
+
+// Drops every connection without a close frame, like a node restart.
+func (s *Server) Disconnect() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for c := range s.conns {
+		c.nc.Close()
+		delete(s.conns, c)
+	}
+	s.notify()
+}
+
+// Returns the options of every subscription to topic,
+// one per subscribed connection.
+func (s *Server) Subscriptions(topic string) []json.RawMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var r []json.RawMessage
+	for _, topics := range s.conns {
+		if options, ok := topics[topic]; ok {
+			r = append(r, options)
+		}
+	}
+
+	return r
+}
+
+// Waits until a connection is subscribed to topic.
+func (s *Server) WaitSubscribed(ctx context.Context, topic string) error {
+	for {
+		s.mu.Lock()
+		subscribed := false
+		for _, topics := range s.conns {
+			if _, ok := topics[topic]; ok {
+				subscribed = true
+			}
+		}
+		changed := s.changed
+		s.mu.Unlock()
+
+		if subscribed {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// Sends message, encoded as JSON, to every connection subscribed to
+// topic. Returns the number of connections it was sent to.
+func (s *Server) Publish(topic string, message interface{}) (int, error) {
+	raw, err := json.Marshal(map[string]interface{}{
+		"topic":   topic,
+		"time":    strconv.FormatInt(time.Now().UnixMilli(), 10),
+		"message": message,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	var targets []*conn
+	for c, topics := range s.conns {
+		if _, ok := topics[topic]; ok {
+			targets = append(targets, c)
+		}
+	}
+	s.mu.Unlock()
+
+	n := 0
+	for _, c := range targets {
+		if c.write(opText, raw) == nil {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// Must be called with s.mu held.
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Header.Get("Upgrade") != "websocket" || key == "" {
+		http.Error(w, "websocket upgrade required", http.StatusBadRequest)
+		return
+	}
+
+	nc, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		return
+	}
+
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n\r\n")
+	if err = rw.Flush(); err != nil {
+		nc.Close()
+		return
+	}
+
+	c := &conn{nc: nc, br: rw.Reader}
+	s.mu.Lock()
+	s.conns[c] = make(map[string]json.RawMessage)
+	s.mu.Unlock()
+
+	defer func() {
+		nc.Close()
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.notify()
+		s.mu.Unlock()
+	}()
+
+	for {
+		opcode, payload, err := c.read()
+		if err != nil {
+			return
+		}
+
+		switch opcode {
+		case opPing:
+			c.write(opPong, payload)
+			continue
+		case opClose:
+			c.write(opClose, nil)
+			return
+		case opText:
+		default:
+			continue
+		}
+
+		s.handle(c, payload)
+	}
+}
+
+// Handles a request of the client.
+func (s *Server) handle(c *conn, payload []byte) {
+	var req struct {
+		Action  string          `json:"action"`
+		Topic   string          `json:"topic"`
+		Options json.RawMessage `json:"options"`
+		Ack     bool            `json:"ack"`
+		ID      string          `json:"id"`
+	}
+	if json.Unmarshal(payload, &req) != nil {
+		return
+	}
+
+	s.mu.Lock()
+	topics, ok := s.conns[c]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+
+	switch req.Action {
+	case "subscribe":
+		topics[req.Topic] = req.Options
+	case "unsubscribe":
+		delete(topics, req.Topic)
+	default:
+		s.mu.Unlock()
+		return
+	}
+	s.notify()
+	ack := req.Ack && !s.noAck
+	s.mu.Unlock()
+
+	if !ack {
+		return
+	}
+
+	raw, _ := json.Marshal(map[string]string{
+		"ack":  req.Action,
+		"time": strconv.FormatInt(time.Now().UnixMilli(), 10),
+		"id":   req.ID,
+	})
+	c.write(opText, raw)
+}
+
+// Reads a single frame, unmasking it.
+// Clients don't fragment their requests.
+func (c *conn) read() (byte, []byte, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.br, head[:]); err != nil {
+		return 0, nil, err
+	}
+
+	n := uint64(head[1] & 0x7f)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+
+	var mask [4]byte
+	if head[1]&0x80 != 0 {
+		if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return 0, nil, err
+	}
+
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return head[0] & 0x0f, payload, nil
+}
+
+// Writes a single unmasked frame, as servers do.
+func (c *conn) write(opcode byte, payload []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	header := []byte{0x80 | opcode}
+	n := len(payload)
+	switch {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xffff:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	_, err := c.nc.Write(append(header, payload...))
+
+	return err
+}
@@ -1,6 +1,8 @@
 package nano
 
 import (
+	"context"
+
 	"github.com/s1na/nano-go/rpc"
 )
 
@@ -8,28 +10,38 @@ type Wallet struct {
 	Id       string
 	Accounts map[string]*Account
 	Seed     string
+
+	wallets rpc.WalletController
+	ledger  rpc.LedgerReader
 }
 
-func NewWallet() *Wallet {
+// Returns a wallet managed through wallets, whose
+// accounts read their state through ledger.
+func NewWallet(wallets rpc.WalletController, ledger rpc.LedgerReader) *Wallet {
 	w := new(Wallet)
 	w.Accounts = make(map[string]*Account)
+	w.wallets = wallets
+	w.ledger = ledger
 
 	return w
 }
 
-func (w *Wallet) CreateAccount() (*Account, error) {
-	a := NewAccount()
-
-	a.Id, err := rpc.CreateAccount(w.Id, true)
+func (w *Wallet) CreateAccount(ctx context.Context) (*Account, error) {
+	id, err := w.wallets.CreateAccount(ctx, w.Id, true)
 	if err != nil {
 		return nil, err
 	}
 
+	a := NewAccount(w.ledger)
+	a.Id = id
+	w.Accounts[id] = a
+
 	return a, nil
 }
 
-func (w *Wallet) Accounts() ([]*Account, error) {
-	ids, err := rpc.AccountList(w.Id)
+// Returns the accounts of the wallet, as known by the node.
+func (w *Wallet) ListAccounts(ctx context.Context) ([]*Account, error) {
+	ids, err := w.wallets.AccountList(ctx, w.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +50,7 @@ func (w *Wallet) Accounts() ([]*Account, error) {
 	for i, id := range ids {
 		a, ok := w.Accounts[id]
 		if !ok {
-			a = NewAccount()
+			a = NewAccount(w.ledger)
 			a.Id = id
 			w.Accounts[id] = a
 		}
@@ -46,5 +58,5 @@ func (w *Wallet) Accounts() ([]*Account, error) {
 		accounts[i] = a
 	}
 
-	return accounts
+	return accounts, nil
 }